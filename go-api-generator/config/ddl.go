@@ -0,0 +1,750 @@
+package config
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseSQL 从 SQLite/MySQL 的 CREATE TABLE 脚本导入配置
+// 支持列类型、PRIMARY KEY、AUTOINCREMENT/AUTO_INCREMENT、UNIQUE、NOT NULL、DEFAULT、
+// CHECK (col IN (...)) / ENUM(...) 枚举、COMMENT 以及列级和表级 FOREIGN KEY;
+// created_at/updated_at 列由生成器自动添加, 导入时跳过; 有 deleted_at 列的表启用软删除, 有版本列的表启用乐观锁
+func (p *Parser) ParseSQL(data []byte) (*models.SchemaConfig, error) {
+	config, err := importDDL(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("解析SQL失败: %w", err)
+	}
+	if err := p.Validate(config); err != nil {
+		return nil, fmt.Errorf("配置验证失败: %w", err)
+	}
+	return config, nil
+}
+
+// autoTimeColumns 生成器自动维护的时间列
+var autoTimeColumns = map[string]bool{"created_at": true, "updated_at": true}
+
+// softDeleteColumn 软删除时间列, 导入时转换为表的 softDelete 选项
+const softDeleteColumn = "deleted_at"
+
+// isVersionColumn 判断是否为乐观锁版本列（名为 version、非空、默认值为 1 的整数列）, 导入时转换为表的 version 选项
+func isVersionColumn(field models.Field) bool {
+	return field.Name == "version" && field.IsInteger() && field.Required && fmt.Sprint(field.Default) == "1"
+}
+
+// sqlToken SQL 词法单元
+type sqlToken struct {
+	text   string
+	quoted bool // 带引号的标识符或字符串
+	str    bool // 单引号字符串字面量
+}
+
+// is 判断是否为指定的关键字或符号（不区分大小写, 带引号的标识符不算关键字）
+func (t sqlToken) is(words ...string) bool {
+	if t.quoted {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// ddlTable 解析中的表
+type ddlTable struct {
+	table         models.Table
+	primaryKey    []string
+	foreignKeys   []ddlForeignKey
+	autoIncrement map[string]bool // 声明了 AUTOINCREMENT/AUTO_INCREMENT 的列
+	integer       map[string]bool // 类型为 INTEGER 的列, SQLite 中 INTEGER PRIMARY KEY 自动递增
+}
+
+// ddlForeignKey 外键约束
+type ddlForeignKey struct {
+	column, refTable, refColumn string
+}
+
+// importDDL 解析 DDL 脚本中的建表和唯一索引语句, 其余语句忽略
+func importDDL(src string) (*models.SchemaConfig, error) {
+	var tables []*ddlTable
+	for _, stmt := range splitStatements(tokenizeSQL(src)) {
+		switch {
+		case len(stmt) > 2 && stmt[0].is("CREATE") && hasTableKeyword(stmt):
+			t, err := parseCreateTable(stmt)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, t)
+		case len(stmt) > 3 && stmt[0].is("CREATE") && stmt[1].is("UNIQUE"):
+			applyUniqueIndex(stmt, tables)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("未找到 CREATE TABLE 语句")
+	}
+
+	config := &models.SchemaConfig{Version: "1.0", Description: "从 SQL DDL 导入"}
+	for _, t := range tables {
+		ensurePrimaryKey(t)
+		config.Tables = append(config.Tables, t.table)
+	}
+	config.Relations = ddlRelations(tables)
+	return config, nil
+}
+
+// hasTableKeyword 判断 CREATE 语句是否为 CREATE [TEMP|TEMPORARY] TABLE
+func hasTableKeyword(stmt []sqlToken) bool {
+	return stmt[1].is("TABLE") || (stmt[1].is("TEMP", "TEMPORARY") && stmt[2].is("TABLE"))
+}
+
+// parseCreateTable 解析 CREATE TABLE 语句
+func parseCreateTable(stmt []sqlToken) (*ddlTable, error) {
+	i := 1
+	for i < len(stmt) && !stmt[i].is("TABLE") {
+		i++
+	}
+	i++
+	if i+2 < len(stmt) && stmt[i].is("IF") && stmt[i+1].is("NOT") && stmt[i+2].is("EXISTS") {
+		i += 3
+	}
+	if i >= len(stmt) {
+		return nil, fmt.Errorf("CREATE TABLE 缺少表名")
+	}
+	name := stmt[i].text
+	// schema.table 只保留表名
+	if i+2 < len(stmt) && stmt[i+1].text == "." {
+		i += 2
+		name = stmt[i].text
+	}
+	i++
+	if i >= len(stmt) || stmt[i].text != "(" {
+		return nil, fmt.Errorf("表 %s: 不支持的 CREATE TABLE 语法（如 CREATE TABLE ... AS SELECT）", name)
+	}
+	end := matchParen(stmt, i)
+	if end < 0 {
+		return nil, fmt.Errorf("表 %s: 括号不匹配", name)
+	}
+
+	t := &ddlTable{
+		table:         models.Table{Name: name, Description: name},
+		autoIncrement: make(map[string]bool),
+		integer:       make(map[string]bool),
+	}
+	for _, def := range splitTopLevel(stmt[i+1 : end]) {
+		if len(def) == 0 {
+			continue
+		}
+		if isTableConstraint(def) {
+			t.parseTableConstraint(def)
+			continue
+		}
+		if err := t.parseColumn(def); err != nil {
+			return nil, fmt.Errorf("表 %s: %w", name, err)
+		}
+	}
+
+	// 表选项: MySQL 的 COMMENT='...'
+	options := stmt[end+1:]
+	for j := 0; j < len(options); j++ {
+		if options[j].is("COMMENT") {
+			if j+1 < len(options) && options[j+1].text == "=" {
+				j++
+			}
+			if j+1 < len(options) && options[j+1].str {
+				t.table.Description = options[j+1].text
+			}
+		}
+	}
+	return t, nil
+}
+
+// isTableConstraint 判断定义是否为表级约束
+func isTableConstraint(def []sqlToken) bool {
+	return def[0].is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "KEY", "INDEX", "CHECK", "FULLTEXT", "SPATIAL")
+}
+
+// parseTableConstraint 解析表级约束: PRIMARY KEY、UNIQUE、FOREIGN KEY 和 CHECK
+func (t *ddlTable) parseTableConstraint(def []sqlToken) {
+	if def[0].is("CONSTRAINT") && len(def) > 2 {
+		def = def[2:]
+	}
+	switch {
+	case def[0].is("PRIMARY"):
+		t.primaryKey = parenIdents(def)
+	case def[0].is("UNIQUE"):
+		if cols := parenIdents(def); len(cols) == 1 {
+			if f := t.field(cols[0]); f != nil {
+				f.Unique = true
+			}
+		}
+	case def[0].is("FOREIGN"):
+		cols := parenIdents(def)
+		for j := range def {
+			if def[j].is("REFERENCES") && len(cols) == 1 {
+				refTable, refCols := referenceTarget(def[j+1:])
+				fk := ddlForeignKey{column: cols[0], refTable: refTable}
+				if len(refCols) == 1 {
+					fk.refColumn = refCols[0]
+				}
+				t.foreignKeys = append(t.foreignKeys, fk)
+				break
+			}
+		}
+	case def[0].is("CHECK"):
+		if col, values := checkEnum(def[1:]); col != "" {
+			if f := t.field(col); f != nil && len(f.Enum) == 0 {
+				f.Enum = values
+			}
+		}
+	}
+}
+
+// parseColumn 解析列定义
+func (t *ddlTable) parseColumn(def []sqlToken) error {
+	name := def[0].text
+	i := 1
+
+	// 类型: 可能由多个单词组成（如 DOUBLE PRECISION、INT UNSIGNED）, 后跟括号参数
+	var typeWords []string
+	var typeArgs []sqlToken
+	for i < len(def) && !def[i].quoted && def[i].text != "(" && !isColumnConstraint(def[i]) {
+		typeWords = append(typeWords, strings.ToUpper(def[i].text))
+		i++
+		if i < len(def) && def[i].text == "(" {
+			end := matchParen(def, i)
+			if end < 0 {
+				return fmt.Errorf("列 %s: 括号不匹配", name)
+			}
+			typeArgs = def[i+1 : end]
+			i = end + 1
+		}
+	}
+	field := models.Field{Name: name}
+	mapSQLType(&field, typeWords, typeArgs)
+	if len(typeWords) == 1 && typeWords[0] == "INTEGER" {
+		t.integer[name] = true
+	}
+
+	for ; i < len(def); i++ {
+		tok := def[i]
+		switch {
+		case tok.is("PRIMARY"):
+			t.primaryKey = []string{name}
+			field.Required = true
+			if i+1 < len(def) && def[i+1].is("KEY") {
+				i++
+			}
+		case tok.is("AUTOINCREMENT", "AUTO_INCREMENT"):
+			t.autoIncrement[name] = true
+		case tok.is("NOT") && i+1 < len(def) && def[i+1].is("NULL"):
+			field.Required = true
+			i++
+		case tok.is("UNIQUE"):
+			field.Unique = true
+		case tok.is("DEFAULT") && i+1 < len(def):
+			var value []sqlToken
+			value, i = defaultValue(def, i+1)
+			field.Default = ddlDefault(field.Type, value)
+		case tok.is("COMMENT") && i+1 < len(def) && def[i+1].str:
+			field.Comment = def[i+1].text
+			i++
+		case tok.is("REFERENCES") && i+1 < len(def):
+			refTable, refCols := referenceTarget(def[i+1:])
+			fk := ddlForeignKey{column: name, refTable: refTable}
+			if len(refCols) == 1 {
+				fk.refColumn = refCols[0]
+			}
+			t.foreignKeys = append(t.foreignKeys, fk)
+			i = skipReference(def, i+1)
+		case tok.is("CHECK") && i+1 < len(def) && def[i+1].text == "(":
+			end := matchParen(def, i+1)
+			if end < 0 {
+				end = len(def) - 1
+			}
+			if col, values := checkEnum(def[i+1 : end+1]); col == name && len(field.Enum) == 0 {
+				field.Enum = values
+			}
+			i = end
+		}
+	}
+
+	if autoTimeColumns[name] {
+		return nil
+	}
+	if name == softDeleteColumn {
+		t.table.SoftDelete = true
+		return nil
+	}
+	if isVersionColumn(field) {
+		t.table.Version = true
+		return nil
+	}
+	t.table.Fields = append(t.table.Fields, field)
+	return nil
+}
+
+// skipReference 跳过 REFERENCES 之后的表名、列名和 ON DELETE/ON UPDATE 动作, 返回最后一个单元的位置
+func skipReference(def []sqlToken, i int) int {
+	if i+1 < len(def) && def[i+1].text == "." {
+		i += 2
+	}
+	if i+1 < len(def) && def[i+1].text == "(" {
+		if end := matchParen(def, i+1); end > 0 {
+			i = end
+		}
+	}
+	for i+2 < len(def) && def[i+1].is("ON") && def[i+2].is("DELETE", "UPDATE") {
+		i += 2
+		switch {
+		case i+2 < len(def) && def[i+1].is("SET", "NO"):
+			i += 2
+		case i+1 < len(def):
+			i++
+		}
+	}
+	return i
+}
+
+// isColumnConstraint 判断单词是否开始一个列约束, 用于结束类型名
+func isColumnConstraint(tok sqlToken) bool {
+	return tok.is("PRIMARY", "NOT", "NULL", "UNIQUE", "DEFAULT", "REFERENCES", "CHECK", "CONSTRAINT",
+		"AUTOINCREMENT", "AUTO_INCREMENT", "COMMENT", "COLLATE", "GENERATED", "ON", "KEY", "AS")
+}
+
+// mapSQLType 将 SQL 列类型映射为配置中的字段类型
+func mapSQLType(field *models.Field, words []string, args []sqlToken) {
+	typ := strings.Join(words, " ")
+	base := ""
+	if len(words) > 0 {
+		base = words[0]
+	}
+	size, scale := 0, 0
+	if len(args) > 0 {
+		size, _ = strconv.Atoi(args[0].text)
+	}
+	// 参数之间的逗号也是词法单元, 如 DECIMAL(10,2) 为 10 , 2
+	if len(args) > 2 {
+		scale, _ = strconv.Atoi(args[2].text)
+	}
+
+	switch {
+	case base == "BOOL" || base == "BOOLEAN" || ((base == "TINYINT" || base == "BIT") && size == 1):
+		field.Type = "boolean"
+	case strings.HasSuffix(base, "INT") || base == "INTEGER":
+		field.Type = "number"
+	case (base == "DECIMAL" || base == "NUMERIC" || base == "DEC") && size > 0 && size <= 65 && scale <= size:
+		// 定点数保留精度, 以字符串存储
+		field.Type = "decimal"
+		field.Precision, field.Scale = size, scale
+	case base == "REAL" || base == "FLOAT" || base == "DOUBLE" || base == "DECIMAL" || base == "NUMERIC" || base == "DEC":
+		field.Type = "float"
+	case base == "DATE":
+		field.Type = "date"
+	case base == "DATETIME" || strings.HasPrefix(base, "TIMESTAMP"):
+		field.Type = "datetime"
+	case base == "TIME" || base == "TIMETZ":
+		field.Type = "time"
+	case base == "JSON" || base == "JSONB":
+		field.Type = "json"
+	case strings.Contains(base, "BLOB") || base == "BYTEA" || base == "BINARY" || base == "VARBINARY":
+		field.Type = "binary"
+		if base == "VARBINARY" {
+			field.Length = size
+		}
+	case base == "ENUM":
+		field.Type = "enum"
+		for _, a := range args {
+			if a.str {
+				field.Enum = append(field.Enum, a.text)
+			}
+		}
+	case strings.Contains(typ, "CHAR") || base == "STRING":
+		field.Type = "string"
+		field.Length = size
+	default:
+		// TEXT、CLOB 及未知类型
+		field.Type = "text"
+	}
+}
+
+// defaultValue 读取 DEFAULT 后的值, 返回值的词法单元和最后一个单元的位置
+func defaultValue(def []sqlToken, i int) ([]sqlToken, int) {
+	if def[i].text == "(" {
+		end := matchParen(def, i)
+		if end < 0 {
+			return def[i:], len(def) - 1
+		}
+		return def[i : end+1], end
+	}
+	if (def[i].text == "-" || def[i].text == "+") && i+1 < len(def) {
+		return []sqlToken{{text: def[i].text + def[i+1].text}}, i + 1
+	}
+	return def[i : i+1], i
+}
+
+// ddlDefault 将 DEFAULT 值转换为配置中的默认值, 表达式（如 CURRENT_TIMESTAMP）和 NULL 忽略
+func ddlDefault(fieldType string, value []sqlToken) any {
+	if len(value) != 1 {
+		return nil
+	}
+	v := value[0]
+	switch fieldType {
+	case "boolean":
+		switch {
+		case v.is("TRUE", "1") || v.text == "'1'":
+			return true
+		case v.is("FALSE", "0") || v.text == "'0'":
+			return false
+		}
+		if v.str {
+			if b, err := strconv.ParseBool(v.text); err == nil {
+				return b
+			}
+		}
+	case "number", "float":
+		// MySQL 中数值默认值常写作字符串, 如 DEFAULT '0.00'
+		if n, err := strconv.ParseFloat(v.text, 64); err == nil {
+			return n
+		}
+	case "decimal":
+		// 字符串存储的 decimal 保留默认值的原始写法, 如 0.00
+		if _, err := strconv.ParseFloat(v.text, 64); err == nil {
+			return v.text
+		}
+	case "string", "text", "enum", "time":
+		if v.str {
+			return v.text
+		}
+	}
+	return nil
+}
+
+// checkEnum 从 CHECK (col IN ('a', 'b')) 中提取列名和枚举值
+func checkEnum(expr []sqlToken) (string, []any) {
+	if len(expr) > 0 && expr[0].text == "(" {
+		expr = expr[1:]
+	}
+	if len(expr) < 4 || !expr[1].is("IN") || expr[2].text != "(" {
+		return "", nil
+	}
+	end := matchParen(expr, 2)
+	if end < 0 {
+		return "", nil
+	}
+	var values []any
+	for _, v := range expr[3:end] {
+		switch {
+		case v.text == ",":
+		case v.str:
+			values = append(values, v.text)
+		default:
+			n, err := strconv.ParseFloat(v.text, 64)
+			if err != nil {
+				return "", nil
+			}
+			values = append(values, n)
+		}
+	}
+	return expr[0].text, values
+}
+
+// referenceTarget 解析 REFERENCES 之后的表名和列名
+func referenceTarget(toks []sqlToken) (string, []string) {
+	if len(toks) == 0 {
+		return "", nil
+	}
+	table := toks[0].text
+	if len(toks) > 2 && toks[1].text == "." {
+		table, toks = toks[2].text, toks[2:]
+	}
+	if len(toks) > 1 && toks[1].text == "(" {
+		return table, parenIdents(toks[1:])
+	}
+	return table, nil
+}
+
+// applyUniqueIndex 处理 CREATE UNIQUE INDEX name ON table (col), 只有单列索引标记为唯一
+func applyUniqueIndex(stmt []sqlToken, tables []*ddlTable) {
+	for i := range stmt {
+		if !stmt[i].is("ON") || i+1 >= len(stmt) {
+			continue
+		}
+		cols := parenIdents(stmt[i+1:])
+		if len(cols) != 1 {
+			return
+		}
+		for _, t := range tables {
+			if t.table.Name == stmt[i+1].text {
+				if f := t.field(cols[0]); f != nil {
+					f.Unique = true
+				}
+			}
+		}
+		return
+	}
+}
+
+// ensurePrimaryKey 设置主键; 没有主键或复合主键包含不支持的类型时添加自增 id 列
+func ensurePrimaryKey(t *ddlTable) {
+	if len(t.primaryKey) == 1 && t.field(t.primaryKey[0]) != nil {
+		pk := t.field(t.primaryKey[0])
+		pk.Required = true
+		// SQLite 的 INTEGER PRIMARY KEY 是 rowid 别名, 自动递增
+		pk.AutoIncrement = pk.Type == "number" && (t.autoIncrement[pk.Name] || t.integer[pk.Name])
+		t.table.PrimaryKey = pk.Name
+		return
+	}
+	if len(t.primaryKey) > 1 && t.keyColumnsSupported() {
+		for _, name := range t.primaryKey {
+			t.field(name).Required = true
+		}
+		t.table.PrimaryKey = strings.Join(t.primaryKey, ",")
+		return
+	}
+
+	if len(t.primaryKey) > 1 {
+		fmt.Printf("   ⚠️  表 %s 的复合主键 (%s) 包含 number/string 以外的列, 已改为自增 id 主键\n", t.table.Name, strings.Join(t.primaryKey, ", "))
+	} else {
+		fmt.Printf("   ⚠️  表 %s 没有主键, 已添加自增 id 主键\n", t.table.Name)
+	}
+	if f := t.field("id"); f != nil {
+		f.Required, f.AutoIncrement = true, f.Type == "number"
+	} else {
+		id := models.Field{Name: "id", Type: "number", Required: true, AutoIncrement: true, Comment: "主键ID"}
+		t.table.Fields = append([]models.Field{id}, t.table.Fields...)
+	}
+	t.table.PrimaryKey = "id"
+}
+
+// keyColumnsSupported 判断复合主键的列是否都存在且为 number 或 string 类型
+func (t *ddlTable) keyColumnsSupported() bool {
+	for _, name := range t.primaryKey {
+		f := t.field(name)
+		if f == nil || (f.Type != "number" && f.Type != "string") {
+			return false
+		}
+	}
+	return true
+}
+
+// field 按列名查找字段
+func (t *ddlTable) field(name string) *models.Field {
+	for i := range t.table.Fields {
+		if t.table.Fields[i].Name == name {
+			return &t.table.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ddlRelations 根据外键生成关系
+// 恰好有两个外键且其余列只有主键的表, 或以两个外键为复合主键的表视为多对多中间表;
+// 外键列唯一时为一对一, 否则为一对多
+func ddlRelations(tables []*ddlTable) []models.Relation {
+	byName := make(map[string]*ddlTable)
+	for _, t := range tables {
+		byName[t.table.Name] = t
+	}
+
+	var relations []models.Relation
+	for _, t := range tables {
+		var fks []ddlForeignKey
+		for _, fk := range t.foreignKeys {
+			target, ok := byName[fk.refTable]
+			if !ok || t.field(fk.column) == nil {
+				fmt.Printf("   ⚠️  表 %s 的外键 %s 引用了未定义的表 %s, 已跳过\n", t.table.Name, fk.column, fk.refTable)
+				continue
+			}
+			if len(target.table.PrimaryKeys()) > 1 {
+				fmt.Printf("   ⚠️  表 %s 的外键 %s 引用了复合主键表 %s, 已跳过\n", t.table.Name, fk.column, fk.refTable)
+				continue
+			}
+			if fk.refColumn == "" {
+				fk.refColumn = target.table.PrimaryKey
+			}
+			fks = append(fks, fk)
+		}
+
+		joinTable := isJoinTable(t, fks)
+		for _, fk := range fks {
+			rel := models.Relation{From: t.table.Name, To: fk.refTable, ForeignKey: fk.column, ReferenceKey: fk.refColumn}
+			switch {
+			case joinTable:
+				rel.Type = "many-to-many"
+			case t.field(fk.column).Unique:
+				rel.Type = "one-to-one"
+			default:
+				rel.Type = "one-to-many"
+			}
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
+
+// isJoinTable 判断表是否为多对多中间表
+// 有 deleted_at 列的表按普通表处理: GORM 的多对多关联不识别中间表的软删除
+func isJoinTable(t *ddlTable, fks []ddlForeignKey) bool {
+	if len(fks) != 2 || fks[0].column == fks[1].column || t.table.SoftDelete {
+		return false
+	}
+	if len(t.primaryKey) == 2 {
+		keys := map[string]bool{t.primaryKey[0]: true, t.primaryKey[1]: true}
+		if keys[fks[0].column] && keys[fks[1].column] {
+			return true
+		}
+	}
+	for _, f := range t.table.Fields {
+		if !t.table.IsPrimaryKey(f.Name) && f.Name != fks[0].column && f.Name != fks[1].column {
+			return false
+		}
+	}
+	return true
+}
+
+// ===================== 词法分析 =====================
+
+// tokenizeSQL 将 SQL 脚本切分为词法单元, 去掉注释
+func tokenizeSQL(src string) []sqlToken {
+	var tokens []sqlToken
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i += 2
+		case r == '\'' || r == '"' || r == '`' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			var sb strings.Builder
+			i++
+			for i < len(runes) {
+				if runes[i] == closing {
+					// 连续两个引号表示转义
+					if i+1 < len(runes) && runes[i+1] == closing && closing != ']' {
+						sb.WriteRune(closing)
+						i += 2
+						continue
+					}
+					break
+				}
+				if runes[i] == '\\' && r == '\'' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			i++
+			tokens = append(tokens, sqlToken{text: sb.String(), quoted: true, str: r == '\''})
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$' ||
+				runes[i] == '.' && unicode.IsDigit(runes[start])) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{text: string(runes[start:i])})
+		default:
+			tokens = append(tokens, sqlToken{text: string(r)})
+			i++
+		}
+	}
+	return tokens
+}
+
+// splitStatements 按分号切分语句
+func splitStatements(tokens []sqlToken) [][]sqlToken {
+	var stmts [][]sqlToken
+	start := 0
+	for i, tok := range tokens {
+		if tok.text == ";" && !tok.quoted {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		stmts = append(stmts, tokens[start:])
+	}
+	return stmts
+}
+
+// splitTopLevel 按最外层的逗号切分
+func splitTopLevel(tokens []sqlToken) [][]sqlToken {
+	var parts [][]sqlToken
+	depth, start := 0, 0
+	for i, tok := range tokens {
+		if tok.quoted {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 0 {
+				parts = append(parts, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tokens[start:])
+}
+
+// matchParen 返回与 open 位置左括号匹配的右括号位置, 不匹配时返回 -1
+func matchParen(tokens []sqlToken, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		if tokens[i].quoted {
+			continue
+		}
+		switch tokens[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parenIdents 返回第一对括号中的标识符列表, 忽略排序方向和前缀长度
+func parenIdents(tokens []sqlToken) []string {
+	for i, tok := range tokens {
+		if tok.text != "(" || tok.quoted {
+			continue
+		}
+		end := matchParen(tokens, i)
+		if end < 0 {
+			return nil
+		}
+		var idents []string
+		for _, part := range splitTopLevel(tokens[i+1 : end]) {
+			if len(part) > 0 {
+				idents = append(idents, part[0].text)
+			}
+		}
+		return idents
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-api-generator/models"
+)
+
+// TestImportDDL 从 CREATE TABLE 语句推断表、字段和关系
+func TestImportDDL(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		primaryKey map[string]string         // 表名 -> 主键
+		fields     map[string][]models.Field // 表名 -> 需要校验的字段
+		relations  []models.Relation
+		comment    map[string]string // 表名 -> 表说明
+	}{
+		{
+			name: "行内主键和列约束",
+			sql: `CREATE TABLE product (
+				id INTEGER PRIMARY KEY,
+				name VARCHAR(50) NOT NULL UNIQUE,
+				status VARCHAR(20) DEFAULT 'draft',
+				stock INTEGER NOT NULL DEFAULT 0,
+				price REAL DEFAULT -1.5,
+				active BOOLEAN DEFAULT TRUE,
+				note TEXT DEFAULT NULL,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);`,
+			primaryKey: map[string]string{"product": "id"},
+			fields: map[string][]models.Field{"product": {
+				{Name: "id", Type: "number", Required: true, AutoIncrement: true},
+				{Name: "name", Type: "string", Length: 50, Required: true, Unique: true},
+				{Name: "status", Type: "string", Length: 20, Default: "draft"},
+				{Name: "stock", Type: "number", Required: true, Default: float64(0)},
+				{Name: "price", Type: "float", Default: -1.5},
+				{Name: "active", Type: "boolean", Default: true},
+				{Name: "note", Type: "text"},
+			}},
+		},
+		{
+			name: "表级主键和唯一约束",
+			sql: `CREATE TABLE member (
+				team_id INTEGER NOT NULL,
+				user_id INTEGER NOT NULL,
+				code VARCHAR(10),
+				PRIMARY KEY (team_id, user_id),
+				UNIQUE (code)
+			);
+			CREATE TABLE tag (id INTEGER PRIMARY KEY, slug VARCHAR(30));
+			CREATE UNIQUE INDEX idx_tag_slug ON tag (slug);`,
+			primaryKey: map[string]string{"member": "team_id,user_id", "tag": "id"},
+			fields: map[string][]models.Field{
+				"member": {{Name: "code", Type: "string", Length: 10, Unique: true}},
+				"tag":    {{Name: "slug", Type: "string", Length: 30, Unique: true}},
+			},
+		},
+		{
+			name: "外键推断关系",
+			sql: `CREATE TABLE author (id INTEGER PRIMARY KEY, name VARCHAR(50));
+			CREATE TABLE post (
+				id INTEGER PRIMARY KEY,
+				author_id INTEGER REFERENCES author(id) ON DELETE CASCADE
+			);
+			CREATE TABLE profile (
+				id INTEGER PRIMARY KEY,
+				author_id INTEGER NOT NULL UNIQUE,
+				FOREIGN KEY (author_id) REFERENCES author (id)
+			);
+			CREATE TABLE tag (id INTEGER PRIMARY KEY);
+			CREATE TABLE post_tag (
+				post_id INTEGER NOT NULL REFERENCES post(id),
+				tag_id INTEGER NOT NULL REFERENCES tag(id),
+				PRIMARY KEY (post_id, tag_id)
+			);`,
+			relations: []models.Relation{
+				{From: "post", To: "author", Type: "one-to-many", ForeignKey: "author_id", ReferenceKey: "id"},
+				{From: "profile", To: "author", Type: "one-to-one", ForeignKey: "author_id", ReferenceKey: "id"},
+				{From: "post_tag", To: "post", Type: "many-to-many", ForeignKey: "post_id", ReferenceKey: "id"},
+				{From: "post_tag", To: "tag", Type: "many-to-many", ForeignKey: "tag_id", ReferenceKey: "id"},
+			},
+		},
+		{
+			name: "MySQL 反引号, COMMENT 和 ENUM",
+			sql: "CREATE TABLE `order` (\n" +
+				"  `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '主键',\n" +
+				"  `status` ENUM('new','paid') NOT NULL DEFAULT 'new' COMMENT '状态',\n" +
+				"  `amount` DECIMAL(10,2) NOT NULL,\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='订单';",
+			primaryKey: map[string]string{"order": "id"},
+			comment:    map[string]string{"order": "订单"},
+			fields: map[string][]models.Field{"order": {
+				{Name: "id", Type: "number", Required: true, AutoIncrement: true, Comment: "主键"},
+				{Name: "status", Type: "enum", Required: true, Default: "new", Comment: "状态", Enum: []any{"new", "paid"}},
+				{Name: "amount", Type: "decimal", Precision: 10, Scale: 2, Required: true},
+			}},
+		},
+		{
+			name: "CHECK IN 推断枚举",
+			sql: `CREATE TABLE task (
+				id INTEGER PRIMARY KEY,
+				level INTEGER CHECK (level IN (1, 2, 3)),
+				state VARCHAR(10),
+				CHECK (state IN ('open', 'done'))
+			);`,
+			fields: map[string][]models.Field{"task": {
+				{Name: "level", Type: "number", Enum: []any{float64(1), float64(2), float64(3)}},
+				{Name: "state", Type: "string", Length: 10, Enum: []any{"open", "done"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := importDDL(tt.sql)
+			if err != nil {
+				t.Fatalf("导入失败: %v", err)
+			}
+			tables := map[string]models.Table{}
+			for _, table := range cfg.Tables {
+				tables[table.Name] = table
+			}
+			for name, want := range tt.primaryKey {
+				if got := tables[name].PrimaryKey; got != want {
+					t.Errorf("表 %s 主键 = %q, 期望 %q", name, got, want)
+				}
+			}
+			for name, want := range tt.comment {
+				if got := tables[name].Description; got != want {
+					t.Errorf("表 %s 说明 = %q, 期望 %q", name, got, want)
+				}
+			}
+			for name, want := range tt.fields {
+				table, ok := tables[name]
+				if !ok {
+					t.Fatalf("缺少表 %s", name)
+				}
+				for _, wf := range want {
+					gf := findField(&table, wf.Name)
+					if gf == nil {
+						t.Errorf("表 %s 缺少字段 %s", name, wf.Name)
+						continue
+					}
+					if !reflect.DeepEqual(*gf, wf) {
+						t.Errorf("表 %s 字段不一致\n实际: %+v\n期望: %+v", name, *gf, wf)
+					}
+				}
+			}
+			if tt.relations != nil && !reflect.DeepEqual(cfg.Relations, tt.relations) {
+				t.Errorf("关系不一致\n实际: %+v\n期望: %+v", cfg.Relations, tt.relations)
+			}
+		})
+	}
+}
+
+// TestImportDDLSkipsExpressionDefault 表达式默认值的时间戳列由生成器自动维护, 导入时跳过
+func TestImportDDLSkipsExpressionDefault(t *testing.T) {
+	cfg, err := importDDL(`CREATE TABLE log (id INTEGER PRIMARY KEY, created_at DATETIME DEFAULT CURRENT_TIMESTAMP);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findField(&cfg.Tables[0], "created_at") != nil {
+		t.Errorf("created_at 应被跳过: %+v", cfg.Tables[0].Fields)
+	}
+}
+
+// TestImportDDLErrors 无法解析的语句返回带表名的错误
+func TestImportDDLErrors(t *testing.T) {
+	tests := []struct {
+		sql     string
+		wantErr string
+	}{
+		{"CREATE TABLE t (id INT", "表 t: 括号不匹配"},
+		{"CREATE TABLE t AS SELECT 1;", "表 t: 不支持的 CREATE TABLE 语法"},
+		{"SELECT 1;", "未找到 CREATE TABLE 语句"},
+		{"CREATE TABLE", "未找到 CREATE TABLE 语句"},
+	}
+	for _, tt := range tests {
+		_, err := importDDL(tt.sql)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("importDDL(%q) 错误 = %v, 期望包含 %q", tt.sql, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package config
+
+import (
+	"database/sql"
+	"fmt"
+	"go-api-generator/models"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+// Introspect 读取已有 SQLite 数据库的表结构, 生成配置
+// 表和列来自 sqlite_master 与 PRAGMA table_info, 唯一约束来自 PRAGMA index_list,
+// 关系来自 PRAGMA foreign_key_list; 类型映射和关系推断规则与 SQL 建表脚本导入相同
+func (p *Parser) Introspect(dbPath string) (*models.SchemaConfig, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("打开数据库失败: %w", err)
+	}
+	// 只读打开, 避免文件不存在时创建空库或修改已有数据库
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("打开数据库失败: %w", err)
+	}
+	defer db.Close()
+
+	config, err := introspectSQLite(db)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据库结构失败: %w", err)
+	}
+	config.Description = fmt.Sprintf("从 SQLite 数据库 %s 导入", filepath.Base(dbPath))
+	if err := p.Validate(config); err != nil {
+		return nil, fmt.Errorf("配置验证失败: %w", err)
+	}
+	return config, nil
+}
+
+// introspectSQLite 按建表顺序读取所有用户表, 跳过 sqlite_ 开头的内部表
+func introspectSQLite(db *sql.DB) (*models.SchemaConfig, error) {
+	rows, err := db.Query("SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY rowid")
+	if err != nil {
+		return nil, err
+	}
+	var names, ddl []string
+	for rows.Next() {
+		var name string
+		var stmt sql.NullString
+		if err := rows.Scan(&name, &stmt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+		ddl = append(ddl, stmt.String)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("数据库中没有表")
+	}
+
+	var tables []*ddlTable
+	for i, name := range names {
+		t := &ddlTable{
+			table:         models.Table{Name: name, Description: name},
+			autoIncrement: make(map[string]bool),
+			integer:       make(map[string]bool),
+		}
+		if err := t.readColumns(db); err != nil {
+			return nil, fmt.Errorf("表 %s: %w", name, err)
+		}
+		if err := t.readUniqueIndexes(db); err != nil {
+			return nil, fmt.Errorf("表 %s: %w", name, err)
+		}
+		if err := t.readForeignKeys(db); err != nil {
+			return nil, fmt.Errorf("表 %s: %w", name, err)
+		}
+		// AUTOINCREMENT 只出现在建表语句中
+		if len(t.primaryKey) == 1 && strings.Contains(strings.ToUpper(ddl[i]), "AUTOINCREMENT") {
+			t.autoIncrement[t.primaryKey[0]] = true
+		}
+		tables = append(tables, t)
+	}
+
+	config := &models.SchemaConfig{Version: "1.0"}
+	for _, t := range tables {
+		ensurePrimaryKey(t)
+		config.Tables = append(config.Tables, t.table)
+	}
+	// 写出的 JSON 中没有关系时为 [] 而不是 null
+	config.Relations = append([]models.Relation{}, ddlRelations(tables)...)
+	return config, nil
+}
+
+// readColumns 读取 PRAGMA table_info: 列名、类型、NOT NULL、默认值和主键序号
+func (t *ddlTable) readColumns(db *sql.DB) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", quoteIdent(t.table.Name)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	pk := make(map[int]string)
+	for rows.Next() {
+		var (
+			cid, notNull, pkSeq int
+			name, colType       string
+			dflt                sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pkSeq); err != nil {
+			return err
+		}
+		if pkSeq > 0 {
+			pk[pkSeq] = name
+		}
+
+		// 类型按 DDL 的词法规则拆分为单词和括号参数, 如 DECIMAL(10,2)、VARCHAR(50)
+		var words []string
+		var args []sqlToken
+		toks := tokenizeSQL(colType)
+		for j := 0; j < len(toks); j++ {
+			if toks[j].text == "(" {
+				if end := matchParen(toks, j); end > 0 {
+					args = toks[j+1 : end]
+				}
+				break
+			}
+			words = append(words, strings.ToUpper(toks[j].text))
+		}
+		field := models.Field{Name: name, Required: notNull == 1 || pkSeq > 0}
+		mapSQLType(&field, words, args)
+		if len(words) == 1 && words[0] == "INTEGER" {
+			t.integer[name] = true
+		}
+		if dflt.Valid {
+			field.Default = ddlDefault(field.Type, tokenizeSQL(dflt.String))
+		}
+
+		if autoTimeColumns[name] {
+			continue
+		}
+		if name == softDeleteColumn {
+			t.table.SoftDelete = true
+			continue
+		}
+		if isVersionColumn(field) {
+			t.table.Version = true
+			continue
+		}
+		t.table.Fields = append(t.table.Fields, field)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := 1; i <= len(pk); i++ {
+		t.primaryKey = append(t.primaryKey, pk[i])
+	}
+	return nil
+}
+
+// readUniqueIndexes 读取 PRAGMA index_list, 单列唯一索引（UNIQUE 约束或 CREATE UNIQUE INDEX）标记为唯一
+func (t *ddlTable) readUniqueIndexes(db *sql.DB) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA index_list(%s)", quoteIdent(t.table.Name)))
+	if err != nil {
+		return err
+	}
+	var unique []string
+	for rows.Next() {
+		var (
+			seq, isUnique, partial int
+			name, origin           string
+		)
+		if err := rows.Scan(&seq, &name, &isUnique, &origin, &partial); err != nil {
+			rows.Close()
+			return err
+		}
+		// 主键索引和部分索引不代表列唯一
+		if isUnique == 1 && origin != "pk" && partial == 0 {
+			unique = append(unique, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, index := range unique {
+		cols, err := indexColumns(db, index)
+		if err != nil {
+			return err
+		}
+		if len(cols) != 1 {
+			continue
+		}
+		if f := t.field(cols[0]); f != nil {
+			f.Unique = true
+		}
+	}
+	return nil
+}
+
+// indexColumns 读取 PRAGMA index_info, 返回索引包含的列; 表达式列名为空
+func indexColumns(db *sql.DB, index string) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA index_info(%s)", quoteIdent(index)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols []string
+	for rows.Next() {
+		var seqno, cid int
+		var name sql.NullString
+		if err := rows.Scan(&seqno, &cid, &name); err != nil {
+			return nil, err
+		}
+		cols = append(cols, name.String)
+	}
+	return cols, rows.Err()
+}
+
+// readForeignKeys 读取 PRAGMA foreign_key_list, 多列外键不支持, 跳过
+func (t *ddlTable) readForeignKeys(db *sql.DB) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA foreign_key_list(%s)", quoteIdent(t.table.Name)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byID := make(map[int][]ddlForeignKey)
+	var ids []int
+	for rows.Next() {
+		var (
+			id, seq                         int
+			refTable, from                  string
+			to                              sql.NullString
+			onUpdate, onDelete, matchClause string
+		)
+		if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &matchClause); err != nil {
+			return err
+		}
+		if _, ok := byID[id]; !ok {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], ddlForeignKey{column: from, refTable: refTable, refColumn: to.String})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// PRAGMA 按 id 倒序返回外键, 按声明顺序输出关系
+	for i := len(ids) - 1; i >= 0; i-- {
+		fks := byID[ids[i]]
+		if len(fks) != 1 {
+			fmt.Printf("   ⚠️  表 %s 的多列外键 (引用 %s) 不支持, 已跳过\n", t.table.Name, fks[0].refTable)
+			continue
+		}
+		t.foreignKeys = append(t.foreignKeys, fks[0])
+	}
+	return nil
+}
+
+// quoteIdent 为 PRAGMA 参数加双引号
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
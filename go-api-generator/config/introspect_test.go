@@ -0,0 +1,100 @@
+package config
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-api-generator/models"
+)
+
+// TestIntrospect 从临时 SQLite 数据库读取表、字段约束和外键关系
+func TestIntrospect(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shop.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE customer (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			email VARCHAR(100) NOT NULL,
+			name TEXT
+		)`,
+		`CREATE UNIQUE INDEX idx_customer_email ON customer (email)`,
+		`CREATE TABLE orders (
+			id INTEGER PRIMARY KEY,
+			customer_id INTEGER NOT NULL REFERENCES customer(id),
+			status VARCHAR(20) NOT NULL DEFAULT 'new',
+			total DECIMAL(10,2) DEFAULT 0,
+			paid BOOLEAN DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("建表失败: %v\n%s", err, stmt)
+		}
+	}
+	db.Close()
+
+	cfg, err := NewParser().Introspect(dbPath)
+	if err != nil {
+		t.Fatalf("读取数据库失败: %v", err)
+	}
+
+	var names []string
+	for _, table := range cfg.Tables {
+		names = append(names, table.Name)
+	}
+	if want := []string{"customer", "orders"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("表 = %v, 期望 %v", names, want)
+	}
+	if !strings.Contains(cfg.Description, "shop.db") {
+		t.Errorf("说明 = %q, 期望包含数据库文件名", cfg.Description)
+	}
+
+	tests := []struct {
+		table int
+		want  models.Field
+	}{
+		{0, models.Field{Name: "id", Type: "number", Required: true, AutoIncrement: true}},
+		{0, models.Field{Name: "email", Type: "string", Length: 100, Required: true, Unique: true}},
+		{0, models.Field{Name: "name", Type: "text"}},
+		{1, models.Field{Name: "id", Type: "number", Required: true, AutoIncrement: true}},
+		{1, models.Field{Name: "customer_id", Type: "number", Required: true}},
+		{1, models.Field{Name: "status", Type: "string", Length: 20, Required: true, Default: "new"}},
+		{1, models.Field{Name: "total", Type: "decimal", Precision: 10, Scale: 2, Default: "0"}},
+		{1, models.Field{Name: "paid", Type: "boolean", Default: false}},
+	}
+	for _, tt := range tests {
+		table := &cfg.Tables[tt.table]
+		got := findField(table, tt.want.Name)
+		if got == nil {
+			t.Errorf("表 %s 缺少字段 %s", table.Name, tt.want.Name)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("表 %s 字段不一致\n实际: %+v\n期望: %+v", table.Name, *got, tt.want)
+		}
+	}
+	if findField(&cfg.Tables[1], "created_at") != nil {
+		t.Errorf("created_at 由生成器自动维护, 应被跳过")
+	}
+
+	wantRelations := []models.Relation{
+		{From: "orders", To: "customer", Type: "one-to-many", ForeignKey: "customer_id", ReferenceKey: "id"},
+	}
+	if !reflect.DeepEqual(cfg.Relations, wantRelations) {
+		t.Errorf("关系不一致\n实际: %+v\n期望: %+v", cfg.Relations, wantRelations)
+	}
+}
+
+// TestIntrospectMissingFile 数据库文件不存在时返回错误, 不创建空库
+func TestIntrospectMissingFile(t *testing.T) {
+	_, err := NewParser().Introspect(filepath.Join(t.TempDir(), "missing.db"))
+	if err == nil || !strings.Contains(err.Error(), "打开数据库失败") {
+		t.Errorf("错误 = %v, 期望打开数据库失败", err)
+	}
+}
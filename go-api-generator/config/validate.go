@@ -0,0 +1,467 @@
+package config
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"go/token"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// roleNamePattern 角色名格式, 角色名会生成为 Go 常量
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// namePattern 表名和字段名格式, 名称会生成为 Go 标识符和数据库列名
+var namePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// authActions 权限规则支持的操作
+var authActions = map[string]bool{"create": true, "read": true, "update": true, "delete": true}
+
+// fieldTypes 支持的字段类型
+var fieldTypes = []string{"number", "string", "boolean", "text", "date", "float", "bigint", "decimal", "enum", "datetime", "time", "json", "binary"}
+
+// enumFieldTypes 可以配置 enum 的字段类型
+var enumFieldTypes = []string{"number", "float", "string", "text", "enum"}
+
+// decimalStorages decimal 字段支持的存储方式
+var decimalStorages = []string{"text", "cents"}
+
+// relationTypes 支持的关系类型
+var relationTypes = []string{"one-to-one", "one-to-many", "many-to-many"}
+
+// autoFields 生成器自动添加的字段（Go 名称）
+var autoFields = map[string]bool{"CreatedAt": true, "UpdatedAt": true}
+
+// ValidationError 配置中一处错误, Path 为 JSON 路径, 如 tables[0].fields[2].type
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors 配置验证发现的所有错误
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = "\n  - " + err.Error()
+	}
+	return fmt.Sprintf("共 %d 处错误:%s", len(e), strings.Join(lines, ""))
+}
+
+// validator 收集验证错误
+type validator struct {
+	errs ValidationErrors
+}
+
+// add 记录一处错误
+func (v *validator) add(path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate 验证配置合法性, 返回所有错误（ValidationErrors）而不是在第一个错误处停止
+func (p *Parser) Validate(config *models.SchemaConfig) error {
+	v := &validator{}
+	if config.Version == "" {
+		v.add("version", "缺少 version 字段")
+	}
+	if len(config.Tables) == 0 {
+		v.add("tables", "至少需要定义一个表")
+	}
+
+	tables := make(map[string]*models.Table)
+	goNames := make(map[string]string)
+	for i := range config.Tables {
+		table := &config.Tables[i]
+		path := fmt.Sprintf("tables[%d]", i)
+		switch {
+		case table.Name == "":
+			v.add(path+".name", "缺少 name 字段")
+		case tables[table.Name] != nil:
+			v.add(path+".name", "表名重复: %s", table.Name)
+		case !namePattern.MatchString(table.Name):
+			v.add(path+".name", "表名无效: %s (只能包含字母、数字和下划线, 以字母开头)", table.Name)
+		default:
+			tables[table.Name] = table
+			// 表名转换为 Go 类型名后不能相同, 如 order_item 和 orderItem 都生成 OrderItem
+			goName := pascalCase(table.Name)
+			if other, ok := goNames[goName]; ok {
+				v.add(path+".name", "表名 %s 与 %s 生成相同的 Go 类型名 %s", table.Name, other, goName)
+			}
+			goNames[goName] = table.Name
+		}
+		v.validateTable(path, table)
+	}
+
+	for i, rel := range config.Relations {
+		v.validateRelation(fmt.Sprintf("relations[%d]", i), rel, tables)
+	}
+
+	if config.Auth != nil {
+		v.validateAuth(config.Auth, tables)
+	}
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// validateTable 验证表的主键和字段
+func (v *validator) validateTable(path string, table *models.Table) {
+	if len(table.Fields) == 0 {
+		v.add(path+".fields", "至少需要一个字段")
+	}
+
+	fieldNames := make(map[string]bool)
+	goNames := make(map[string]string)
+	for j, field := range table.Fields {
+		fieldPath := fmt.Sprintf("%s.fields[%d]", path, j)
+		switch {
+		case field.Name == "":
+			v.add(fieldPath+".name", "缺少 name 字段")
+		case fieldNames[field.Name]:
+			v.add(fieldPath+".name", "字段名重复: %s", field.Name)
+		case !namePattern.MatchString(field.Name):
+			v.add(fieldPath+".name", "字段名无效: %s (只能包含字母、数字和下划线, 以字母开头)", field.Name)
+		default:
+			fieldNames[field.Name] = true
+			goName := pascalCase(field.Name)
+			if autoFields[goName] || table.SoftDelete && goName == "DeletedAt" || table.Version && goName == "Version" {
+				v.add(fieldPath+".name", "字段 %s 由生成器自动添加, 不需要在配置中定义", field.Name)
+			} else if other, ok := goNames[goName]; ok {
+				v.add(fieldPath+".name", "字段名 %s 与 %s 生成相同的 Go 字段名 %s", field.Name, other, goName)
+			}
+			goNames[goName] = field.Name
+		}
+		v.validateField(fieldPath, field)
+	}
+
+	v.validatePrimaryKey(path, table)
+}
+
+// validatePrimaryKey 验证主键: 字段存在, 类型为 number 或 string, 复合主键不能自增
+func (v *validator) validatePrimaryKey(path string, table *models.Table) {
+	keys := table.PrimaryKeys()
+	if table.PrimaryKey != "" && len(keys) == 0 {
+		v.add(path+".primaryKey", "主键无效: %s", table.PrimaryKey)
+		return
+	}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		field := findField(table, key)
+		switch {
+		case seen[key]:
+			v.add(path+".primaryKey", "主键字段重复: %s", key)
+		case field == nil:
+			v.add(path+".primaryKey", "主键 %s 不在字段列表中", key)
+		case field.Type != "number" && field.Type != "string":
+			v.add(path+".primaryKey", "主键 %s 的类型为 %s, 只支持 number 或 string", key, field.Type)
+		case len(keys) > 1 && field.AutoIncrement:
+			v.add(path+".primaryKey", "复合主键中的字段 %s 不能自增", key)
+		}
+		seen[key] = true
+	}
+}
+
+// validateField 验证字段类型以及与类型相关的属性
+func (v *validator) validateField(path string, field models.Field) {
+	switch {
+	case field.Type == "":
+		v.add(path+".type", "缺少 type 字段")
+		return
+	case !contains(fieldTypes, field.Type):
+		v.add(path+".type", "类型无效: %s (支持: %s)", field.Type, strings.Join(fieldTypes, ", "))
+		return
+	}
+
+	if field.Length < 0 {
+		v.add(path+".length", "length 不能为负数")
+	} else if field.Length > 0 && field.Type != "string" && field.Type != "enum" && field.Type != "binary" {
+		v.add(path+".length", "length 只适用于 string、enum、binary 类型, 当前类型为 %s", field.Type)
+	}
+	if field.AutoIncrement && field.Type != "number" {
+		v.add(path+".autoIncrement", "autoIncrement 只适用于 number 类型, 当前类型为 %s", field.Type)
+	}
+	v.validateDecimal(path, field)
+	// json 和 binary 列无法建立普通唯一索引, 也不支持列默认值
+	if field.Type == "json" || field.Type == "binary" {
+		if field.Unique {
+			v.add(path+".unique", "%s 类型不支持 unique", field.Type)
+		}
+		if field.Default != nil {
+			v.add(path+".default", "%s 类型不支持 default", field.Type)
+		}
+	}
+
+	if field.Type == "enum" && len(field.Enum) == 0 {
+		v.add(path+".enum", "enum 类型必须配置非空的 enum 列表")
+		return
+	}
+	if len(field.Enum) > 0 && !contains(enumFieldTypes, field.Type) {
+		v.add(path+".enum", "enum 只适用于 %s 类型, 当前类型为 %s", strings.Join(enumFieldTypes, "、"), field.Type)
+		return
+	}
+	for k, value := range field.Enum {
+		if !enumValueMatches(field.Type, value) {
+			v.add(fmt.Sprintf("%s.enum[%d]", path, k), "枚举值 %v 与字段类型 %s 不匹配", formatEnumValue(value), field.Type)
+		}
+	}
+}
+
+// validateDecimal 验证 decimal 字段的 precision、scale 和 storage, 其他类型不能配置这些属性
+func (v *validator) validateDecimal(path string, field models.Field) {
+	if field.Type != "decimal" {
+		if field.Precision != 0 {
+			v.add(path+".precision", "precision 只适用于 decimal 类型, 当前类型为 %s", field.Type)
+		}
+		if field.Scale != 0 {
+			v.add(path+".scale", "scale 只适用于 decimal 类型, 当前类型为 %s", field.Type)
+		}
+		if field.Storage != "" {
+			v.add(path+".storage", "storage 只适用于 decimal 类型, 当前类型为 %s", field.Type)
+		}
+		return
+	}
+
+	precision := field.DecimalPrecision()
+	switch {
+	case field.Precision < 0 || precision > 65:
+		v.add(path+".precision", "precision 必须在 1-65 之间")
+	case field.Scale < 0 || field.Scale > precision:
+		v.add(path+".scale", "scale 必须在 0-%d 之间（不能超过 precision）", precision)
+	}
+	if field.Storage != "" && !contains(decimalStorages, field.Storage) {
+		v.add(path+".storage", "storage 无效: %s (支持: %s)", field.Storage, strings.Join(decimalStorages, ", "))
+	}
+	// cents 存储为 int64, 最多容纳 18 位十进制数
+	if field.IsCents() && precision > 18 {
+		v.add(path+".precision", "storage 为 cents 时 precision 不能超过 18")
+	}
+}
+
+// enumValueMatches 判断枚举值的 JSON 类型是否与字段类型一致
+func enumValueMatches(fieldType string, value any) bool {
+	switch fieldType {
+	case "number":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "float":
+		_, ok := value.(float64)
+		return ok
+	default:
+		_, ok := value.(string)
+		return ok
+	}
+}
+
+// formatEnumValue 格式化错误信息中的枚举值, 字符串带引号以区分 "1" 和 1
+func formatEnumValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// validateRelation 验证关系的表、类型、外键和引用字段
+func (v *validator) validateRelation(path string, rel models.Relation, tables map[string]*models.Table) {
+	from, to := tables[rel.From], tables[rel.To]
+	if rel.From == "" {
+		v.add(path+".from", "缺少 from 字段")
+	} else if from == nil {
+		v.add(path+".from", "引用了不存在的表: %s", rel.From)
+	}
+	if rel.To == "" {
+		v.add(path+".to", "缺少 to 字段")
+	} else if to == nil {
+		v.add(path+".to", "引用了不存在的表: %s", rel.To)
+	}
+	if !contains(relationTypes, rel.Type) {
+		v.add(path+".type", "关系类型无效: %s (支持: %s)", rel.Type, strings.Join(relationTypes, ", "))
+	}
+	// GORM 的多对多关联直接增删中间表记录, 不识别 deleted_at
+	if rel.Type == "many-to-many" && from != nil && from.SoftDelete {
+		v.add(path+".from", "多对多中间表 %s 不能启用软删除", rel.From)
+	}
+
+	switch {
+	case rel.ForeignKey == "":
+		v.add(path+".foreignKey", "缺少 foreignKey 字段")
+	case from != nil && findField(from, rel.ForeignKey) == nil:
+		v.add(path+".foreignKey", "外键 %s 不是表 %s 的字段", rel.ForeignKey, rel.From)
+	case token.IsKeyword(camelCase(rel.ForeignKey)):
+		// 外键会生成为查询方法的参数名, 如外键 type 生成 ListByType(type int64, ...)
+		v.add(path+".foreignKey", "外键 %s 转换后是 Go 关键字, 无法作为参数名", rel.ForeignKey)
+	}
+	if to == nil {
+		return
+	}
+	// 复合主键表的路由为 /:a/:b, 不能再挂 /:id/xxx 嵌套路由
+	if len(to.PrimaryKeys()) > 1 {
+		v.add(path+".to", "表 %s 使用复合主键, 不能作为关系的引用表", rel.To)
+		return
+	}
+	if rel.ReferenceKey != "" {
+		ref := findField(to, rel.ReferenceKey)
+		if ref == nil {
+			v.add(path+".referenceKey", "引用字段 %s 不是表 %s 的字段", rel.ReferenceKey, rel.To)
+			return
+		}
+		// 外键约束只能引用主键或唯一列
+		if !ref.Unique && !to.IsPrimaryKey(ref.Name) {
+			v.add(path+".referenceKey", "引用字段 %s 不是表 %s 的主键或唯一字段", rel.ReferenceKey, rel.To)
+		}
+	}
+
+	// 外键与引用字段（默认为主键）类型一致, 生成的查询参数和路径参数使用同一 Go 类型
+	ref := rel.ReferenceKey
+	if ref == "" {
+		ref = to.PrimaryKey
+	}
+	if from == nil || ref == "" {
+		return
+	}
+	fk, refField := findField(from, rel.ForeignKey), findField(to, ref)
+	if fk != nil && refField != nil && fk.Type != refField.Type {
+		v.add(path+".foreignKey", "外键 %s 的类型 %s 与引用字段 %s.%s 的类型 %s 不一致", rel.ForeignKey, fk.Type, rel.To, ref, refField.Type)
+	}
+}
+
+// validateAuth 补全认证配置的默认值并验证
+// 规则中的角色可以是 roles 中的角色, 或 * (任意登录用户)、public (无需登录)
+func (v *validator) validateAuth(auth *models.AuthConfig, tables map[string]*models.Table) {
+	if auth.UsersTable == "" {
+		auth.UsersTable = "users"
+	}
+	if len(auth.Roles) == 0 {
+		auth.Roles = []string{"admin", "user"}
+	}
+	if auth.DefaultRole == "" {
+		auth.DefaultRole = auth.Roles[len(auth.Roles)-1]
+	}
+	if auth.TokenTTL == "" {
+		auth.TokenTTL = "24h"
+	}
+
+	if tables[auth.UsersTable] != nil {
+		v.add("auth.usersTable", "用户表 %s 与已定义的表重名", auth.UsersTable)
+	}
+	roles := make(map[string]bool)
+	for i, role := range auth.Roles {
+		path := fmt.Sprintf("auth.roles[%d]", i)
+		switch {
+		case !roleNamePattern.MatchString(role):
+			v.add(path, "角色名无效: %s (只能包含小写字母、数字和下划线)", role)
+		case role == "public":
+			v.add(path, "角色名 public 为保留字")
+		case roles[role]:
+			v.add(path, "角色重复: %s", role)
+		}
+		roles[role] = true
+	}
+	if !roles[auth.DefaultRole] {
+		v.add("auth.defaultRole", "defaultRole %s 不在 roles 中", auth.DefaultRole)
+	}
+	if ttl, err := time.ParseDuration(auth.TokenTTL); err != nil || ttl <= 0 {
+		v.add("auth.tokenTTL", "tokenTTL 无效: %s", auth.TokenTTL)
+	}
+
+	// 按键排序, 使错误顺序稳定
+	for _, table := range sortedKeys(auth.Rules) {
+		tablePath := keyPath("auth.rules", table)
+		if table != "*" && tables[table] == nil {
+			v.add(tablePath, "规则中引用了不存在的表: %s", table)
+		}
+		actions := auth.Rules[table]
+		for _, action := range sortedKeys(actions) {
+			actionPath := keyPath(tablePath, action)
+			if !authActions[action] {
+				v.add(actionPath, "操作无效: %s (支持: create, read, update, delete)", action)
+			}
+			for i, role := range actions[action] {
+				if role != "*" && role != "public" && !roles[role] {
+					v.add(fmt.Sprintf("%s[%d]", actionPath, i), "引用了未定义的角色: %s", role)
+				}
+			}
+		}
+	}
+}
+
+// findField 按名称查找表中的字段
+func findField(table *models.Table, name string) *models.Field {
+	for i := range table.Fields {
+		if table.Fields[i].Name == name {
+			return &table.Fields[i]
+		}
+	}
+	return nil
+}
+
+// contains 判断切片是否包含指定字符串
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys 返回排序后的 map 键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keyPath 拼接 JSON 路径, 非标识符的键使用 ["key"] 形式, 如 auth.rules["*"]
+func keyPath(base, key string) string {
+	if namePattern.MatchString(key) {
+		return base + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", base, key)
+}
+
+// pascalCase 与 generator.ToPascalCase 规则相同（config 包不能依赖 generator）,
+// 用于检查名称转换为 Go 标识符后是否冲突
+func pascalCase(s string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' || r == ' ' }) {
+		switch upper := strings.ToUpper(part); upper {
+		case "ID", "UUID", "URL", "API", "HTTP", "JSON", "XML", "SQL", "IP", "HTML", "CSS":
+			sb.WriteString(upper)
+		default:
+			runes := []rune(part)
+			runes[0] = unicode.ToUpper(runes[0])
+			sb.WriteString(string(runes))
+		}
+	}
+	return sb.String()
+}
+
+// camelCase 首字母小写的 pascalCase, 只用于判断是否为 Go 关键字（关键字都是小写单词）
+func camelCase(s string) string {
+	pascal := []rune(pascalCase(s))
+	if len(pascal) == 0 {
+		return ""
+	}
+	pascal[0] = unicode.ToLower(pascal[0])
+	return string(pascal)
+}
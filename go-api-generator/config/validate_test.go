@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestValidateCollectsErrors 一次验证报告所有错误及其 JSON 路径
+func TestValidateCollectsErrors(t *testing.T) {
+	data := `{
+		"version": "1.0",
+		"tables": [
+			{"name": "order_item", "primaryKey": "id", "fields": [
+				{"name": "id", "type": "float", "length": 10, "autoIncrement": true},
+				{"name": "status", "type": "number", "enum": [1, "2"]},
+				{"name": "created_at", "type": "date"},
+				{"name": "amount", "type": "decimal", "precision": 4, "scale": 6},
+				{"name": "kind", "type": "enum"},
+				{"name": "meta", "type": "json", "unique": true, "scale": 2}
+			]},
+			{"name": "orderItem", "primaryKey": "id", "fields": [
+				{"name": "id", "type": "number"},
+				{"name": "type", "type": "number"}
+			]},
+			{"name": "member", "primaryKey": "team_id,user_id", "softDelete": true, "version": true, "fields": [
+				{"name": "team_id", "type": "number", "autoIncrement": true},
+				{"name": "user_id", "type": "number"},
+				{"name": "code", "type": "string"},
+				{"name": "deleted_at", "type": "date"},
+				{"name": "version", "type": "number"}
+			]}
+		],
+		"relations": [
+			{"from": "orderItem", "to": "order_item", "type": "one-to-many", "foreignKey": "order_item_id"},
+			{"from": "orderItem", "to": "order_item", "type": "one-to-many", "foreignKey": "type", "referenceKey": "code"},
+			{"from": "orderItem", "to": "member", "type": "one-to-many", "foreignKey": "id"},
+			{"from": "member", "to": "orderItem", "type": "one-to-many", "foreignKey": "code"},
+			{"from": "member", "to": "orderItem", "type": "many-to-many", "foreignKey": "user_id"},
+			{"from": "orderItem", "to": "order_item", "type": "one-to-many", "foreignKey": "id", "referenceKey": "status"}
+		],
+		"auth": {"roles": ["admin"], "rules": {"*": {"read": ["guest"]}}}
+	}`
+	_, err := NewParser().Parse([]byte(data))
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("期望 ValidationErrors, 实际: %v", err)
+	}
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	want := []string{
+		"tables[0].fields[0].length",
+		"tables[0].fields[0].autoIncrement",
+		"tables[0].fields[1].enum[1]",
+		"tables[0].fields[2].name",
+		"tables[0].fields[3].scale",
+		"tables[0].fields[4].enum",
+		"tables[0].fields[5].scale",
+		"tables[0].fields[5].unique",
+		"tables[0].primaryKey",
+		"tables[1].name",
+		"tables[2].fields[3].name",
+		"tables[2].fields[4].name",
+		"tables[2].primaryKey",
+		"relations[0].foreignKey",
+		"relations[1].foreignKey",
+		"relations[1].referenceKey",
+		"relations[2].to",
+		"relations[3].foreignKey",
+		"relations[4].from",
+		"relations[5].referenceKey",
+		`auth.rules["*"].read[0]`,
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("错误路径不一致\n实际: %q\n期望: %q\n%v", paths, want, err)
+	}
+}
+
+// TestSchemaMatchesValidator JSON Schema 中的字段类型和关系类型与验证规则一致
+func TestSchemaMatchesValidator(t *testing.T) {
+	data, err := os.ReadFile("config.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("config.schema.json 不是合法 JSON: %v", err)
+	}
+	if got := schema.Definitions["field"].Properties["type"].Enum; !reflect.DeepEqual(got, fieldTypes) {
+		t.Errorf("字段类型不一致: schema %v, 验证 %v", got, fieldTypes)
+	}
+	if got := schema.Definitions["relation"].Properties["type"].Enum; !reflect.DeepEqual(got, relationTypes) {
+		t.Errorf("关系类型不一致: schema %v, 验证 %v", got, relationTypes)
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-api-generator/models"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stringKeys 配置中字符串类型字段的键名, YAML 中这些键的值按原文读取（如 version: 1.0 读取为 "1.0"）
+var stringKeys = collectStringKeys(reflect.TypeOf(models.SchemaConfig{}), map[string]bool{})
+
+// ParseYAML 从 YAML 解析配置, 键名与 JSON 配置相同
+// YAML 先转换为 JSON 再解析, 与 JSON 配置共用字段定义和验证
+func (p *Parser) ParseYAML(data []byte) (*models.SchemaConfig, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("解析YAML失败: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, fmt.Errorf("解析YAML失败: 文件为空")
+	}
+	value, err := yamlValue(root.Content[0], false)
+	if err != nil {
+		return nil, fmt.Errorf("解析YAML失败: %w", err)
+	}
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("转换YAML失败: %w", err)
+	}
+	return p.Parse(jsonData)
+}
+
+// yamlValue 将 YAML 节点转换为可序列化为 JSON 的值, raw 为 true 时标量按原文返回字符串
+func yamlValue(node *yaml.Node, raw bool) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlValue(node.Alias, raw)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			value, err := yamlValue(node.Content[i+1], stringKeys[key])
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		list := make([]any, len(node.Content))
+		for i, item := range node.Content {
+			value, err := yamlValue(item, raw)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return list, nil
+	case yaml.ScalarNode:
+		if raw && node.Tag != "!!null" {
+			return node.Value, nil
+		}
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", node.Line, err)
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("第 %d 行: 不支持的 YAML 节点", node.Line)
+}
+
+// collectStringKeys 收集结构体（含嵌套结构体）中字符串及字符串切片字段的 json 键名
+func collectStringKeys(t reflect.Type, keys map[string]bool) map[string]bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		ft := field.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.String && name != "" {
+			keys[name] = true
+		}
+		collectStringKeys(field.Type, keys)
+	}
+	return keys
+}
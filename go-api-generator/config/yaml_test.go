@@ -0,0 +1,82 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseYAMLMatchesJSON 相同内容的 YAML 和 JSON 配置解析结果一致
+func TestParseYAMLMatchesJSON(t *testing.T) {
+	yamlData := `
+version: 1.0
+description: 商品目录
+tables:
+  - name: product
+    description: 商品
+    primaryKey: id
+    fields:
+      - &id { name: id, type: number, required: true, autoIncrement: true, comment: 主键 }
+      - { name: sku, type: string, length: 32, required: true, unique: true }
+      - { name: price, type: decimal, precision: 10, scale: 2, default: "0.00" }
+      - { name: weight, type: float, default: 1.5 }
+      - { name: on_sale, type: boolean, default: true }
+      - { name: level, type: number, default: 1, enum: [1, 2, 3] }
+      - name: status
+        type: string
+        length: 20
+        default: draft
+        enum: [draft, published]
+  - name: review
+    description: 评价
+    primaryKey: id
+    fields:
+      - *id
+      - { name: product_id, type: number, required: true }
+relations:
+  - { from: review, to: product, type: one-to-many, foreignKey: product_id, referenceKey: id }
+`
+	jsonData := `{
+		"version": "1.0",
+		"description": "商品目录",
+		"tables": [
+			{"name": "product", "description": "商品", "primaryKey": "id", "fields": [
+				{"name": "id", "type": "number", "required": true, "autoIncrement": true, "comment": "主键"},
+				{"name": "sku", "type": "string", "length": 32, "required": true, "unique": true},
+				{"name": "price", "type": "decimal", "precision": 10, "scale": 2, "default": "0.00"},
+				{"name": "weight", "type": "float", "default": 1.5},
+				{"name": "on_sale", "type": "boolean", "default": true},
+				{"name": "level", "type": "number", "default": 1, "enum": [1, 2, 3]},
+				{"name": "status", "type": "string", "length": 20, "default": "draft", "enum": ["draft", "published"]}
+			]},
+			{"name": "review", "description": "评价", "primaryKey": "id", "fields": [
+				{"name": "id", "type": "number", "required": true, "autoIncrement": true, "comment": "主键"},
+				{"name": "product_id", "type": "number", "required": true}
+			]}
+		],
+		"relations": [
+			{"from": "review", "to": "product", "type": "one-to-many", "foreignKey": "product_id", "referenceKey": "id"}
+		]
+	}`
+
+	p := NewParser()
+	fromYAML, err := p.ParseYAML([]byte(yamlData))
+	if err != nil {
+		t.Fatalf("解析 YAML 失败: %v", err)
+	}
+	fromJSON, err := p.Parse([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("解析 JSON 失败: %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("YAML 与 JSON 解析结果不一致\nYAML: %+v\nJSON: %+v", fromYAML, fromJSON)
+	}
+}
+
+// TestParseYAMLErrors YAML 语法错误和空文件返回错误
+func TestParseYAMLErrors(t *testing.T) {
+	for _, data := range []string{"", "tables: [\n", "tables:\n  - name: a\n   bad"} {
+		if _, err := NewParser().ParseYAML([]byte(data)); err == nil {
+			t.Errorf("ParseYAML(%q) 期望返回错误", data)
+		}
+	}
+}
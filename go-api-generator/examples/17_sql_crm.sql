@@ -0,0 +1,62 @@
+-- 场景17：SQL DDL 导入 - CRM/ERP（SQLite 建表脚本）
+CREATE TABLE IF NOT EXISTS users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	username VARCHAR(50) UNIQUE NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	email VARCHAR(100),
+	phone VARCHAR(20),
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS customers (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name VARCHAR(100) NOT NULL,
+	company VARCHAR(100),
+	email VARCHAR(100),
+	phone VARCHAR(20),
+	address TEXT,
+	status VARCHAR(20) DEFAULT 'active',
+	user_id INTEGER NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS products (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name VARCHAR(100) NOT NULL,
+	sku VARCHAR(50) UNIQUE NOT NULL,
+	description TEXT,
+	price DECIMAL(10,2) NOT NULL,
+	cost DECIMAL(10,2),
+	category VARCHAR(50),
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS inventory (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	product_id INTEGER NOT NULL,
+	quantity INTEGER DEFAULT 0,
+	warehouse VARCHAR(50),
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	order_no VARCHAR(50) UNIQUE NOT NULL,
+	customer_id INTEGER NOT NULL,
+	product_id INTEGER NOT NULL,
+	quantity INTEGER NOT NULL,
+	unit_price DECIMAL(10,2) NOT NULL,
+	total_amount DECIMAL(10,2) NOT NULL,
+	status VARCHAR(20) DEFAULT 'pending',
+	user_id INTEGER NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (customer_id) REFERENCES customers(id),
+	FOREIGN KEY (product_id) REFERENCES products(id),
+	FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,564 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 权限规则中的操作
+const (
+	authCreate = "create" // POST 创建
+	authRead   = "read"   // GET 列表、详情及嵌套查询
+	authUpdate = "update" // PUT 替换、PATCH 部分更新及多对多关联维护
+	authDelete = "delete" // DELETE 删除及批量删除
+)
+
+// 权限规则中的特殊角色
+const (
+	roleAnyUser = "*"      // 任意登录用户
+	rolePublic  = "public" // 无需登录
+)
+
+// authEnabled 是否生成认证相关代码
+func (g *Generator) authEnabled() bool {
+	return g.Config.Auth != nil
+}
+
+// authUsersTable 认证用户表定义, 与业务表一起生成迁移
+func authUsersTable(auth *models.AuthConfig) models.Table {
+	roles := make([]any, len(auth.Roles))
+	for i, role := range auth.Roles {
+		roles[i] = role
+	}
+	return models.Table{
+		Name:        auth.UsersTable,
+		Description: "用户",
+		PrimaryKey:  "id",
+		Fields: []models.Field{
+			{Name: "id", Type: "number", Required: true, AutoIncrement: true, Comment: "用户ID"},
+			{Name: "username", Type: "string", Length: 50, Required: true, Unique: true, Comment: "用户名"},
+			{Name: "password_hash", Type: "string", Length: 255, Required: true, Comment: "密码哈希"},
+			{Name: "role", Type: "string", Length: 20, Required: true, Default: auth.DefaultRole, Enum: roles, Comment: "角色"},
+		},
+	}
+}
+
+// migrationSchema 生成迁移使用的完整 schema, 启用认证时追加用户表
+func migrationSchema(config *models.SchemaConfig) *models.SchemaConfig {
+	if config == nil || config.Auth == nil {
+		return config
+	}
+	full := *config
+	full.Tables = append(append([]models.Table{}, config.Tables...), authUsersTable(config.Auth))
+	return &full
+}
+
+// authRoles 查询表上某个操作允许的角色
+// 先取表的规则, 再取 * 的默认规则, 都没有时允许任意登录用户; 空列表表示仅管理员
+func (g *Generator) authRoles(table, action string) (roles []string, public bool) {
+	auth := g.Config.Auth
+	if auth == nil {
+		return nil, true
+	}
+	allowed, ok := auth.Rules[table][action]
+	if !ok {
+		allowed, ok = auth.Rules["*"][action]
+	}
+	if !ok {
+		return nil, false
+	}
+	if len(allowed) == 0 {
+		return auth.Roles[:1], false
+	}
+	for _, role := range allowed {
+		switch role {
+		case rolePublic:
+			return nil, true
+		case roleAnyUser:
+			return nil, false
+		}
+	}
+	return allowed, false
+}
+
+// authGuard 路由注册时插入的权限中间件, 公开接口返回空
+func (g *Generator) authGuard(table, action string) string {
+	roles, public := g.authRoles(table, action)
+	if public {
+		return ""
+	}
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		quoted[i] = strconv.Quote(role)
+	}
+	return fmt.Sprintf("middleware.Allow(%s), ", strings.Join(quoted, ", "))
+}
+
+// generateAuth 生成认证相关代码: 用户模型、仓库、JWT 中间件和注册登录处理器
+func (g *Generator) generateAuth() error {
+	if err := g.writeFile("models/auth.go", g.buildAuthModel()); err != nil {
+		return err
+	}
+	if err := g.writeFile("database/auth_repo.go", g.buildAuthRepository()); err != nil {
+		return err
+	}
+	if err := g.writeFile("middleware/auth.go", g.buildAuthMiddleware()); err != nil {
+		return err
+	}
+	return g.writeFile("handlers/auth_handler.go", g.buildAuthHandler())
+}
+
+// buildAuthModel 构建用户模型、角色常量和认证请求结构
+func (g *Generator) buildAuthModel() string {
+	auth := g.Config.Auth
+	var sb strings.Builder
+
+	sb.WriteString("package models\n\nimport \"time\"\n\n")
+
+	sb.WriteString("// 角色\n")
+	width := 0
+	for _, role := range auth.Roles {
+		width = max(width, len(ToPascalCase(role)))
+	}
+	sb.WriteString("const (\n")
+	for _, role := range auth.Roles {
+		sb.WriteString(fmt.Sprintf("\tRole%-*s = %q\n", width, ToPascalCase(role), role))
+	}
+	sb.WriteString(")\n\n")
+	sb.WriteString("// AdminRole 管理员角色, 启动时创建的初始用户使用该角色\n")
+	sb.WriteString(fmt.Sprintf("const AdminRole = Role%s\n\n", ToPascalCase(auth.Roles[0])))
+	sb.WriteString("// DefaultRole 注册用户的角色\n")
+	sb.WriteString(fmt.Sprintf("const DefaultRole = Role%s\n\n", ToPascalCase(auth.DefaultRole)))
+
+	sb.WriteString(`// AuthUser 用户
+type AuthUser struct {
+	ID           int64     ` + "`gorm:\"column:id;primaryKey;autoIncrement\" json:\"id\"`" + `
+	Username     string    ` + "`gorm:\"column:username;type:varchar(50);not null;uniqueIndex\" json:\"username\"`" + `
+	PasswordHash string    ` + "`gorm:\"column:password_hash;type:varchar(255);not null\" json:\"-\"`" + `
+	Role         string    ` + "`gorm:\"column:role;type:varchar(20);not null\" json:\"role\"`" + `
+	CreatedAt    time.Time ` + "`gorm:\"autoCreateTime\" json:\"created_at\"`" + `
+	UpdatedAt    time.Time ` + "`gorm:\"autoUpdateTime\" json:\"updated_at\"`" + `
+}
+
+`)
+	sb.WriteString("// TableName 指定表名\n")
+	sb.WriteString("func (AuthUser) TableName() string {\n")
+	sb.WriteString(fmt.Sprintf("\treturn %q\n", auth.UsersTable))
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(`// RegisterRequest 注册请求
+type RegisterRequest struct {
+	Username string ` + "`json:\"username\" binding:\"required,min=3,max=50\"`" + `
+	Password string ` + "`json:\"password\" binding:\"required,min=6,max=72\"`" + `
+}
+
+// LoginRequest 登录请求
+type LoginRequest struct {
+	Username string ` + "`json:\"username\" binding:\"required\"`" + `
+	Password string ` + "`json:\"password\" binding:\"required\"`" + `
+}
+
+// TokenResponse 登录响应
+type TokenResponse struct {
+	Token     string    ` + "`json:\"token\"`" + `
+	ExpiresAt time.Time ` + "`json:\"expires_at\"`" + `
+	User      AuthUser  ` + "`json:\"user\"`" + `
+}
+`)
+	return sb.String()
+}
+
+// buildAuthRepository 构建用户数据访问层
+func (g *Generator) buildAuthRepository() string {
+	return `package database
+
+import (
+	"errors"
+	"fmt"
+	"` + g.ModName + `/models"
+
+	"gorm.io/gorm"
+)
+
+// AuthUserRepository 用户数据访问层
+type AuthUserRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthUserRepository 创建仓库实例
+func NewAuthUserRepository() *AuthUserRepository {
+	return &AuthUserRepository{db: GetDB()}
+}
+
+// Create 创建用户
+func (r *AuthUserRepository) Create(user *models.AuthUser) error {
+	if err := r.db.Create(user).Error; err != nil {
+		return fmt.Errorf("创建用户失败: %w", err)
+	}
+	return nil
+}
+
+// GetByID 根据ID查询用户, 不存在时返回 nil
+func (r *AuthUserRepository) GetByID(id int64) (*models.AuthUser, error) {
+	return r.first("id = ?", id)
+}
+
+// GetByUsername 根据用户名查询用户, 不存在时返回 nil
+func (r *AuthUserRepository) GetByUsername(username string) (*models.AuthUser, error) {
+	return r.first("username = ?", username)
+}
+
+func (r *AuthUserRepository) first(query string, arg interface{}) (*models.AuthUser, error) {
+	var user models.AuthUser
+	if err := r.db.Where(query, arg).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	return &user, nil
+}
+`
+}
+
+// buildAuthMiddleware 构建 JWT 认证和角色检查中间件
+func (g *Generator) buildAuthMiddleware() string {
+	ttl, _ := time.ParseDuration(g.Config.Auth.TokenTTL)
+
+	return `package middleware
+
+import (
+	"crypto/rand"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenTTL 令牌有效期
+const TokenTTL = ` + goDuration(ttl) + `
+
+// claimsKey 上下文中保存当前用户的键
+const claimsKey = "auth_claims"
+
+// Claims JWT 载荷
+type Claims struct {
+	UserID   int64  ` + "`json:\"user_id\"`" + `
+	Username string ` + "`json:\"username\"`" + `
+	Role     string ` + "`json:\"role\"`" + `
+	jwt.RegisteredClaims
+}
+
+// jwtSecret 签名密钥, 读取环境变量 JWT_SECRET
+var jwtSecret = loadSecret()
+
+// loadSecret 读取签名密钥, 未设置时使用随机密钥（重启后已签发的令牌失效）
+func loadSecret() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	log.Println("⚠️  未设置 JWT_SECRET, 使用随机密钥, 服务重启后已签发的令牌将失效")
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return secret
+}
+
+// GenerateToken 签发令牌, 返回令牌和过期时间
+func GenerateToken(userID int64, username, role string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(TokenTTL)
+	claims := Claims{
+		UserID:   userID,
+		Username: username,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ParseToken 解析并校验令牌
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Allow 认证中间件: 要求请求头携带 Authorization: Bearer <token>
+// 指定角色时还要求当前用户的角色在其中, 否则返回 403
+func Allow(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || tokenString == "" {
+			abortAuth(c, http.StatusUnauthorized, "未登录或缺少令牌")
+			return
+		}
+
+		claims, err := ParseToken(tokenString)
+		if err != nil {
+			abortAuth(c, http.StatusUnauthorized, "令牌无效或已过期")
+			return
+		}
+		if len(roles) > 0 && !slices.Contains(roles, claims.Role) {
+			abortAuth(c, http.StatusForbidden, "权限不足")
+			return
+		}
+
+		c.Set(claimsKey, claims)
+		c.Next()
+	}
+}
+
+// CurrentUser 获取当前登录用户, 只在 Allow 保护的路由中可用
+func CurrentUser(c *gin.Context) (*Claims, bool) {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*Claims)
+	return claims, ok
+}
+
+// abortAuth 终止请求, 响应结构与 handlers.Response 一致
+func abortAuth(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{"code": -1, "message": message})
+}
+`
+}
+
+// goDuration 将时长转为 Go 常量表达式
+func goDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d * time.Hour", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%d * time.Second", d/time.Second)
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+// buildAuthHandler 构建注册、登录和当前用户处理器
+func (g *Generator) buildAuthHandler() string {
+	return `package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"` + g.ModName + `/database"
+	"` + g.ModName + `/middleware"
+	"` + g.ModName + `/models"
+)
+
+// AuthHandler 认证HTTP处理器
+type AuthHandler struct {
+	repo *database.AuthUserRepository
+}
+
+// NewAuthHandler 创建处理器实例
+func NewAuthHandler() *AuthHandler {
+	return &AuthHandler{repo: database.NewAuthUserRepository()}
+}
+
+// Register 注册, 新用户的角色为 models.DefaultRole
+// @Summary 用户注册
+// @Tags Auth
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "参数错误: "+err.Error())
+		return
+	}
+
+	existing, err := h.repo.GetByUsername(req.Username)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	if existing != nil {
+		Error(c, http.StatusConflict, "用户名已存在")
+		return
+	}
+
+	user, err := createUser(h.repo, req.Username, req.Password, models.DefaultRole)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	respondToken(c, user)
+}
+
+// Login 登录, 成功后返回令牌
+// @Summary 用户登录
+// @Tags Auth
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequest(c, "参数错误: "+err.Error())
+		return
+	}
+
+	user, err := h.repo.GetByUsername(req.Username)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	if user == nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+		Error(c, http.StatusUnauthorized, "用户名或密码错误")
+		return
+	}
+	respondToken(c, user)
+}
+
+// Me 获取当前登录用户
+// @Summary 当前用户
+// @Tags Auth
+func (h *AuthHandler) Me(c *gin.Context) {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		Error(c, http.StatusUnauthorized, "未登录")
+		return
+	}
+
+	user, err := h.repo.GetByID(claims.UserID)
+	if err != nil {
+		InternalError(c, err.Error())
+		return
+	}
+	if user == nil {
+		NotFound(c, "用户不存在")
+		return
+	}
+	Success(c, user)
+}
+
+// EnsureAdmin 用户不存在时创建管理员, password 为空时跳过
+func EnsureAdmin(username, password string) error {
+	if password == "" {
+		return nil
+	}
+	repo := database.NewAuthUserRepository()
+	existing, err := repo.GetByUsername(username)
+	if err != nil || existing != nil {
+		return err
+	}
+	_, err = createUser(repo, username, password, models.AdminRole)
+	return err
+}
+
+// createUser 哈希密码并创建用户
+func createUser(repo *database.AuthUserRepository, username, password, role string) (*models.AuthUser, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.AuthUser{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         role,
+	}
+	if err := repo.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// respondToken 签发令牌并返回登录响应
+func respondToken(c *gin.Context, user *models.AuthUser) {
+	token, expiresAt, err := middleware.GenerateToken(user.ID, user.Username, user.Role)
+	if err != nil {
+		InternalError(c, "签发令牌失败: "+err.Error())
+		return
+	}
+	Success(c, models.TokenResponse{Token: token, ExpiresAt: expiresAt, User: *user})
+}
+`
+}
+
+// secure 按权限规则给接口附加 bearerAuth 认证要求和 401/403 响应
+func (g *Generator) secure(table, action string, op map[string]any) map[string]any {
+	if _, public := g.authRoles(table, action); public {
+		return op
+	}
+	return withBearer(op)
+}
+
+// withBearer 给接口附加 bearerAuth 认证要求
+func withBearer(op map[string]any) map[string]any {
+	op["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+	responses := op["responses"].(map[string]any)
+	responses["401"] = errorResponse("未登录或令牌无效")
+	responses["403"] = errorResponse("权限不足")
+	return op
+}
+
+// addAuthPaths 添加注册、登录和当前用户接口
+func (g *Generator) addAuthPaths(paths, schemas map[string]any) {
+	credentials := func(minLength bool) map[string]any {
+		username := map[string]any{"type": "string"}
+		password := map[string]any{"type": "string"}
+		if minLength {
+			username["minLength"], username["maxLength"] = 3, 50
+			password["minLength"], password["maxLength"] = 6, 72
+		}
+		return map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"username": username, "password": password},
+			"required":   []string{"username", "password"},
+		}
+	}
+	schemas["RegisterRequest"] = credentials(true)
+	schemas["LoginRequest"] = credentials(false)
+	schemas["AuthUser"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":         map[string]any{"type": "integer", "format": "int64"},
+			"username":   map[string]any{"type": "string"},
+			"role":       map[string]any{"type": "string", "enum": g.Config.Auth.Roles},
+			"created_at": map[string]any{"type": "string", "format": "date-time"},
+			"updated_at": map[string]any{"type": "string", "format": "date-time"},
+		},
+	}
+	schemas["TokenResponse"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"token":      map[string]any{"type": "string"},
+			"expires_at": map[string]any{"type": "string", "format": "date-time"},
+			"user":       map[string]any{"$ref": "#/components/schemas/AuthUser"},
+		},
+	}
+
+	token := dataResponse("#/components/schemas/TokenResponse")
+	register := operation("Auth", "用户注册", nil, jsonBody("#/components/schemas/RegisterRequest"), token)
+	register["responses"].(map[string]any)["409"] = errorResponse("用户名已存在")
+	login := operation("Auth", "用户登录", nil, jsonBody("#/components/schemas/LoginRequest"), token)
+	login["responses"].(map[string]any)["401"] = errorResponse("用户名或密码错误")
+
+	paths["/api/v1/auth/register"] = map[string]any{"post": register}
+	paths["/api/v1/auth/login"] = map[string]any{"post": login}
+	paths["/api/v1/auth/me"] = map[string]any{
+		"get": withBearer(operation("Auth", "获取当前用户", nil, nil, dataResponse("#/components/schemas/AuthUser"))),
+	}
+}
@@ -0,0 +1,676 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strings"
+)
+
+// generateClient 生成 Go 客户端 SDK: 公共请求逻辑、类型化错误和每个模型的接口方法
+// 客户端直接复用 models 包中的模型和 DTO
+func (g *Generator) generateClient() error {
+	if err := g.writeFile("client/client.go", g.buildClientCore()); err != nil {
+		return err
+	}
+	if g.authEnabled() {
+		if err := g.writeFile("client/auth.go", g.buildClientAuth()); err != nil {
+			return err
+		}
+	}
+
+	for _, model := range g.Models {
+		filename := fmt.Sprintf("client/%s.go", strings.ToLower(model.TableName))
+		if err := g.writeFile(filename, g.buildClientModel(model)); err != nil {
+			return fmt.Errorf("写入客户端文件失败 %s: %w", model.Name, err)
+		}
+	}
+	return nil
+}
+
+// buildClientCore 构建客户端公共代码
+func (g *Generator) buildClientCore() string {
+	var sb strings.Builder
+
+	sb.WriteString(`// Package client 是生成的 API 的 Go 客户端
+//
+//	c := client.New("http://localhost:8080")
+//	page, err := c.ListXxxs(ctx, models.QueryXxxParams{Page: 1})
+//	if errors.Is(err, client.ErrNotFound) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+`)
+	if g.usesVersion() {
+		sb.WriteString("\t\"strconv\"\n")
+	}
+	sb.WriteString("\t\"strings\"\n")
+	if g.authEnabled() {
+		sb.WriteString("\t\"sync\"\n")
+	}
+	sb.WriteString(`	"time"
+)
+
+// 按 HTTP 状态码区分的错误, 可用 errors.Is 判断 *APIError
+var (
+	ErrBadRequest   = errors.New("参数错误")
+	ErrUnauthorized = errors.New("未登录或令牌无效")
+	ErrForbidden    = errors.New("权限不足")
+	ErrNotFound     = errors.New("资源不存在")
+	ErrConflict     = errors.New("资源冲突")
+	ErrInternal     = errors.New("服务器内部错误")
+`)
+	if g.usesVersion() {
+		sb.WriteString(`
+	// ErrPreconditionFailed 记录已被其他请求修改（版本号与 WithVersion 指定的不一致）
+	ErrPreconditionFailed = errors.New("记录已被修改")
+`)
+	}
+	sb.WriteString(`)
+
+// statusErrors HTTP 状态码到错误的映射
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+`)
+	if g.usesVersion() {
+		sb.WriteString("\thttp.StatusPreconditionFailed:  ErrPreconditionFailed,\n")
+	}
+	sb.WriteString(`	http.StatusInternalServerError: ErrInternal,
+}
+
+// APIError 接口返回的错误
+type APIError struct {
+	StatusCode int          // HTTP 状态码
+	Code       int          // 响应中的 code
+	Message    string       // 响应中的 message
+	Items      []FailedItem // 批量请求中出错的记录
+}
+
+// FailedItem 批量请求中出错的记录, 与 handlers.FailedItem 一致; 导入时 Row 为记录在文件中的行号
+type FailedItem struct {
+	Index   int    ` + "`json:\"index\"`" + `
+	Row     int    ` + "`json:\"row,omitempty\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// importResult 导入成功的响应数据
+type importResult struct {
+	Count int ` + "`json:\"count\"`" + `
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	return fmt.Sprintf("请求失败(%d): %s", e.StatusCode, e.Message)
+}
+
+// Is 按状态码匹配 ErrNotFound 等错误
+func (e *APIError) Is(target error) bool {
+	return statusErrors[e.StatusCode] == target
+}
+
+// Page 分页数据, 与 handlers.PageData 一致; 请求 SkipTotal 时 Total 为 nil
+// 游标分页时把 NextCursor 作为下一次请求的 Cursor, 为空表示没有下一页
+type Page[T any] struct {
+	List       []T    ` + "`json:\"list\"`" + `
+	Total      *int64 ` + "`json:\"total\"`" + `
+	Page       int    ` + "`json:\"page\"`" + `
+	PageSize   int    ` + "`json:\"page_size\"`" + `
+	NextCursor string ` + "`json:\"next_cursor\"`" + `
+}
+
+// response 统一响应结构, 与 handlers.Response 一致
+type response struct {
+	Code    int             ` + "`json:\"code\"`" + `
+	Message string          ` + "`json:\"message\"`" + `
+	Data    json.RawMessage ` + "`json:\"data\"`" + `
+}
+
+// idsRequest 批量操作请求, K 为主键类型
+type idsRequest[K any] struct {
+	IDs []K ` + "`json:\"ids\"`" + `
+}
+
+// itemsRequest 批量创建、更新和 upsert 请求
+type itemsRequest[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+}
+
+// BatchUpdateItem 批量部分更新中的一条记录, K 为主键类型, T 为部分更新请求
+type BatchUpdateItem[K, T any] struct {
+	ID   K ` + "`json:\"id\"`" + `
+	Data T ` + "`json:\"data\"`" + `
+}
+
+// Client API 客户端
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+`)
+	if g.authEnabled() {
+		sb.WriteString("\n\tmu    sync.RWMutex\n")
+		sb.WriteString("\ttoken string\n")
+	}
+	sb.WriteString(`}
+
+// Option 客户端配置项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的 http.Client（超时、代理等）
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+`)
+	if g.authEnabled() {
+		sb.WriteString(`// WithToken 使用已有的令牌
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+`)
+	}
+	sb.WriteString(`// New 创建客户端, baseURL 为服务地址, 如 http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+`)
+	if g.authEnabled() {
+		sb.WriteString(`// SetToken 设置后续请求使用的令牌, Login/Register 成功后会自动设置
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Token 当前使用的令牌
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+`)
+	}
+	sb.WriteString(`// do 发送 JSON 请求并解析统一响应, out 为 nil 时忽略响应数据
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("序列化请求失败: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+
+// download 发送 GET 请求并返回响应体, 用于导出文件, 调用方负责关闭; 失败时返回 *APIError
+func (c *Client) download(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求失败: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, decodeResponse(resp, nil)
+	}
+	return resp.Body, nil
+}
+
+// upload 以 multipart 表单（字段 file）上传文件并解析统一响应, 文件内容边读边发送
+func (c *Client) upload(ctx context.Context, path, filename string, file io.Reader, out any) error {
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil, reader)
+	if err != nil {
+		reader.Close()
+		return err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	return decodeResponse(resp, out)
+}
+`)
+	if g.usesVersion() {
+		sb.WriteString(`
+// versionKey WithVersion 在 context 中保存版本号的键
+type versionKey struct{}
+
+// WithVersion 返回携带版本号的 context, 用它发起的更新和删除请求带上 If-Match,
+// 记录已被其他请求修改时返回 ErrPreconditionFailed; 版本号取自实体的 Version 字段
+func WithVersion(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, versionKey{}, version)
+}
+`)
+	}
+	sb.WriteString(`
+// newRequest 创建请求, path 为不含服务地址的路径
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+`)
+	if g.authEnabled() {
+		sb.WriteString(`	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+`)
+	}
+	if g.usesVersion() {
+		sb.WriteString(`	if version, ok := ctx.Value(versionKey{}).(int64); ok {
+		req.Header.Set("If-Match", strconv.Quote(strconv.FormatInt(version, 10)))
+	}
+`)
+	}
+	sb.WriteString(`	return req, nil
+}
+
+// decodeResponse 解析统一响应, 状态码或 code 表示失败时返回 *APIError, out 为 nil 时忽略响应数据
+func decodeResponse(resp *http.Response, out any) error {
+	var envelope response
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return &APIError{StatusCode: resp.StatusCode, Code: -1, Message: resp.Status}
+		}
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest || envelope.Code != 0 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Code: envelope.Code, Message: envelope.Message}
+		if len(envelope.Data) > 0 {
+			_ = json.Unmarshal(envelope.Data, &apiErr.Items)
+		}
+		return apiErr
+	}
+	if out != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return fmt.Errorf("解析响应数据失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// encodeQuery 按 form 标签将查询参数结构体编码为 URL 参数, 零值和 nil 字段不编码
+func encodeQuery(params any) url.Values {
+	values := url.Values{}
+	v := reflect.ValueOf(params)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Pointer:
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		case field.IsZero():
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			for j := 0; j < field.Len(); j++ {
+				values.Add(name, formatQueryValue(field.Index(j)))
+			}
+			continue
+		}
+		values.Set(name, formatQueryValue(field))
+	}
+	return values
+}
+
+// formatQueryValue 格式化单个查询参数值, 时间使用 RFC3339
+func formatQueryValue(v reflect.Value) string {
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v.Interface())
+}
+`)
+	return sb.String()
+}
+
+// buildClientModel 构建单个模型的客户端方法
+func (g *Generator) buildClientModel(model GoModelWrapper) string {
+	var sb strings.Builder
+	base := fmt.Sprintf("/api/v1/%ss", strings.ToLower(model.TableName))
+	plural := model.Name + "s"
+	desc := model.Description
+	key := keyType(model)
+	item := clientPath(base, keyFields(model), "id", "")
+
+	// 导入由 formatGoSource 删除未使用的部分, 如没有字符串主键时的 net/url
+	sb.WriteString("package client\n\n")
+	sb.WriteString("import (\n")
+	sb.WriteString("\t\"context\"\n")
+	sb.WriteString("\t\"fmt\"\n")
+	sb.WriteString("\t\"io\"\n")
+	sb.WriteString("\t\"net/http\"\n")
+	sb.WriteString("\t\"net/url\"\n")
+	sb.WriteString("\t\"strings\"\n")
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("\t\"%s/models\"\n", g.ModName))
+	sb.WriteString(")\n\n")
+
+	// Create
+	sb.WriteString(fmt.Sprintf("// Create%s 创建%s\n", model.Name, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Create%s(ctx context.Context, req models.Create%sRequest) (*models.%s, error) {\n", model.Name, model.Name, model.Name))
+	sb.WriteString(fmt.Sprintf("\tvar entity models.%s\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodPost, \"%s\", nil, req, &entity); err != nil {\n", base))
+	sb.WriteString("\t\treturn nil, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn &entity, nil\n")
+	sb.WriteString("}\n\n")
+
+	// Get
+	sb.WriteString(fmt.Sprintf("// Get%s 根据ID获取%s\n", model.Name, desc))
+	if hasClientInclude(model) {
+		sb.WriteString(fmt.Sprintf("func (c *Client) Get%s(ctx context.Context, id %s, include ...string) (*models.%s, error) {\n", model.Name, key, model.Name))
+		sb.WriteString("\tvar query url.Values\n")
+		sb.WriteString("\tif len(include) > 0 {\n")
+		sb.WriteString("\t\tquery = url.Values{\"include\": {strings.Join(include, \",\")}}\n")
+		sb.WriteString("\t}\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("func (c *Client) Get%s(ctx context.Context, id %s) (*models.%s, error) {\n", model.Name, key, model.Name))
+	}
+	sb.WriteString(fmt.Sprintf("\tvar entity models.%s\n", model.Name))
+	query := "nil"
+	if hasClientInclude(model) {
+		query = "query"
+	}
+	sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodGet, %s, %s, nil, &entity); err != nil {\n", item, query))
+	sb.WriteString("\t\treturn nil, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn &entity, nil\n")
+	sb.WriteString("}\n\n")
+
+	// List
+	sb.WriteString(fmt.Sprintf("// List%s 分页查询%s列表\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) List%s(ctx context.Context, params models.Query%sParams) (*Page[models.%s], error) {\n", plural, model.Name, model.Name))
+	sb.WriteString(fmt.Sprintf("\tvar page Page[models.%s]\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodGet, \"%s\", encodeQuery(params), nil, &page); err != nil {\n", base))
+	sb.WriteString("\t\treturn nil, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn &page, nil\n")
+	sb.WriteString("}\n\n")
+
+	// Export / Import
+	sb.WriteString(fmt.Sprintf("// Export%s 按查询条件导出%s, format 为 csv 或 xlsx, 调用方负责关闭返回的文件内容\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Export%s(ctx context.Context, format string, params models.Query%sParams) (io.ReadCloser, error) {\n", plural, model.Name))
+	sb.WriteString("\tquery := encodeQuery(params)\n")
+	sb.WriteString("\tquery.Set(\"format\", format)\n")
+	sb.WriteString(fmt.Sprintf("\treturn c.download(ctx, \"%s/export\", query)\n", base))
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("// Import%s 上传 CSV 或 Excel 文件导入%s, 格式由 filename 的扩展名确定, 返回导入的记录数\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Import%s(ctx context.Context, filename string, file io.Reader) (int, error) {\n", plural))
+	sb.WriteString("\tvar result importResult\n")
+	sb.WriteString(fmt.Sprintf("\tif err := c.upload(ctx, \"%s/import\", filename, file, &result); err != nil {\n", base))
+	sb.WriteString("\t\treturn 0, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn result.Count, nil\n")
+	sb.WriteString("}\n\n")
+
+	// Update / Replace
+	sb.WriteString(fmt.Sprintf("// Update%s 部分更新%s, 只发送非 nil 的字段\n", model.Name, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Update%s(ctx context.Context, id %s, req models.Update%sRequest) error {\n", model.Name, key, model.Name))
+	sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodPatch, %s, nil, req, nil)\n", item))
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(fmt.Sprintf("// Replace%s 整体替换%s, 省略的字段恢复默认值\n", model.Name, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Replace%s(ctx context.Context, id %s, req models.Replace%sRequest) error {\n", model.Name, key, model.Name))
+	sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodPut, %s, nil, req, nil)\n", item))
+	sb.WriteString("}\n\n")
+
+	// Delete
+	sb.WriteString(fmt.Sprintf("// Delete%s 删除%s\n", model.Name, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) Delete%s(ctx context.Context, id %s) error {\n", model.Name, key))
+	sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodDelete, %s, nil, nil, nil)\n", item))
+	sb.WriteString("}\n\n")
+
+	// 软删除: Restore / Purge
+	if model.SoftDelete {
+		sb.WriteString(fmt.Sprintf("// Restore%s 从回收站恢复%s\n", model.Name, desc))
+		sb.WriteString(fmt.Sprintf("func (c *Client) Restore%s(ctx context.Context, id %s) error {\n", model.Name, key))
+		sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodPost, %s, nil, nil, nil)\n", clientPath(base, keyFields(model), "id", "/restore")))
+		sb.WriteString("}\n\n")
+
+		sb.WriteString(fmt.Sprintf("// Purge%s 彻底删除%s, 不可恢复\n", model.Name, desc))
+		sb.WriteString(fmt.Sprintf("func (c *Client) Purge%s(ctx context.Context, id %s) error {\n", model.Name, key))
+		sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodDelete, %s, nil, nil, nil)\n", clientPath(base, keyFields(model), "id", "/purge")))
+		sb.WriteString("}\n\n")
+	}
+
+	// BatchCreate / BatchUpdate / Upsert
+	sb.WriteString(fmt.Sprintf("// BatchCreate%s 批量创建%s, 在一个事务中写入, 任一条失败时全部回滚\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) BatchCreate%s(ctx context.Context, items []models.Create%sRequest) ([]models.%s, error) {\n", plural, model.Name, model.Name))
+	sb.WriteString(fmt.Sprintf("\tvar entities []models.%s\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodPost, \"%s/batch\", nil, itemsRequest[models.Create%sRequest]{Items: items}, &entities); err != nil {\n", base, model.Name))
+	sb.WriteString("\t\treturn nil, err\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("\treturn entities, nil\n")
+	sb.WriteString("}\n\n")
+
+	updateItem := fmt.Sprintf("BatchUpdateItem[%s, models.Update%sRequest]", key, model.Name)
+	sb.WriteString(fmt.Sprintf("// BatchUpdate%s 批量部分更新%s, 在一个事务中写入, 任一条失败时全部回滚\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) BatchUpdate%s(ctx context.Context, items []%s) error {\n", plural, updateItem))
+	sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodPatch, \"%s/batch\", nil, itemsRequest[%s]{Items: items}, nil)\n", base, updateItem))
+	sb.WriteString("}\n\n")
+
+	if len(uniqueFields(model)) > 0 {
+		sb.WriteString(fmt.Sprintf("// Upsert%s 按唯一列 on 批量插入或更新%s, on 为空时使用服务端默认的唯一列\n", plural, desc))
+		sb.WriteString(fmt.Sprintf("func (c *Client) Upsert%s(ctx context.Context, on string, items []models.Create%sRequest) ([]models.%s, error) {\n", plural, model.Name, model.Name))
+		sb.WriteString("\tvar query url.Values\n")
+		sb.WriteString("\tif on != \"\" {\n")
+		sb.WriteString("\t\tquery = url.Values{\"on\": {on}}\n")
+		sb.WriteString("\t}\n")
+		sb.WriteString(fmt.Sprintf("\tvar entities []models.%s\n", model.Name))
+		sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodPut, \"%s/upsert\", query, itemsRequest[models.Create%sRequest]{Items: items}, &entities); err != nil {\n", base, model.Name))
+		sb.WriteString("\t\treturn nil, err\n")
+		sb.WriteString("\t}\n")
+		sb.WriteString("\treturn entities, nil\n")
+		sb.WriteString("}\n\n")
+	}
+
+	// BatchDelete
+	sb.WriteString(fmt.Sprintf("// BatchDelete%s 批量删除%s\n", plural, desc))
+	sb.WriteString(fmt.Sprintf("func (c *Client) BatchDelete%s(ctx context.Context, ids []%s) error {\n", plural, key))
+	sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, http.MethodPost, \"%s/batch-delete\", nil, idsRequest[%s]{IDs: ids}, nil)\n", base, key))
+	sb.WriteString("}\n")
+
+	sb.WriteString(g.buildClientAssociations(model, base))
+	return sb.String()
+}
+
+// buildClientAssociations 构建嵌套路由对应的客户端方法
+// 方法名为 动作+关联字段名+By/To/From+本模型, 如 ListOrdersByCustomer, 避免与模型的 ListXxxs 重名
+func (g *Generator) buildClientAssociations(model GoModelWrapper, base string) string {
+	var sb strings.Builder
+
+	for _, assoc := range model.Associations {
+		// has one / has many 的 :id 按外键类型解析, many2many 的 :id 为本模型主键
+		keys := keyFields(model)
+		if target := g.findModel(assoc.TableName); target != nil && assoc.Kind != "many2many" {
+			if fk := findField(*target, assoc.ForeignColumn); fk != nil {
+				keys = []models.GoField{*fk}
+			}
+		}
+		path := clientPath(base, keys, "id", "/"+assoc.JsonName)
+		name := assoc.GoName + "By" + model.Name
+		switch assoc.Kind {
+		case "has-many":
+			sb.WriteString(fmt.Sprintf("\n// List%s 根据%sID分页查询%s列表\n", name, model.Description, assoc.Description))
+			sb.WriteString(fmt.Sprintf("func (c *Client) List%s(ctx context.Context, id %s, params models.Query%sParams) (*Page[models.%s], error) {\n", name, assoc.KeyType, assoc.Model, assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tvar page Page[models.%s]\n", assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodGet, %s, encodeQuery(params), nil, &page); err != nil {\n", path))
+			sb.WriteString("\t\treturn nil, err\n")
+			sb.WriteString("\t}\n")
+			sb.WriteString("\treturn &page, nil\n")
+			sb.WriteString("}\n")
+		case "has-one":
+			sb.WriteString(fmt.Sprintf("\n// Get%s 根据%sID获取%s\n", name, model.Description, assoc.Description))
+			sb.WriteString(fmt.Sprintf("func (c *Client) Get%s(ctx context.Context, id %s) (*models.%s, error) {\n", name, assoc.KeyType, assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tvar entity models.%s\n", assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodGet, %s, nil, nil, &entity); err != nil {\n", path))
+			sb.WriteString("\t\treturn nil, err\n")
+			sb.WriteString("\t}\n")
+			sb.WriteString("\treturn &entity, nil\n")
+			sb.WriteString("}\n")
+		case "many2many":
+			sb.WriteString(fmt.Sprintf("\n// List%s 获取%s关联的%s\n", name, model.Description, assoc.Description))
+			sb.WriteString(fmt.Sprintf("func (c *Client) List%s(ctx context.Context, id %s) ([]models.%s, error) {\n", name, keyType(model), assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tvar items []models.%s\n", assoc.Model))
+			sb.WriteString(fmt.Sprintf("\tif err := c.do(ctx, http.MethodGet, %s, nil, nil, &items); err != nil {\n", path))
+			sb.WriteString("\t\treturn nil, err\n")
+			sb.WriteString("\t}\n")
+			sb.WriteString("\treturn items, nil\n")
+			sb.WriteString("}\n")
+			for _, action := range []struct{ Method, HTTP, Label string }{
+				{"Add" + assoc.GoName + "To", "http.MethodPost", "添加"},
+				{"Remove" + assoc.GoName + "From", "http.MethodDelete", "移除"},
+			} {
+				sb.WriteString(fmt.Sprintf("\n// %s%s %s%s关联的%s\n", action.Method, model.Name, action.Label, model.Description, assoc.Description))
+				sb.WriteString(fmt.Sprintf("func (c *Client) %s%s(ctx context.Context, id %s, ids []%s) error {\n", action.Method, model.Name, keyType(model), assoc.KeyType))
+				sb.WriteString(fmt.Sprintf("\treturn c.do(ctx, %s, %s, nil, idsRequest[%s]{IDs: ids}, nil)\n", action.HTTP, path, assoc.KeyType))
+				sb.WriteString("}\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// clientPath 构建请求路径的 Go 表达式, 整数主键用 %d, 字符串主键经 url.PathEscape 转义;
+// 复合主键时 v 为 XxxKey 结构体, 每个字段一段路径
+func clientPath(base string, keys []models.GoField, v, suffix string) string {
+	var verbs, args []string
+	for _, f := range keys {
+		arg := v
+		if len(keys) > 1 {
+			arg = v + "." + f.GoName
+		}
+		if strings.TrimPrefix(f.GoType, "*") == "int64" {
+			verbs = append(verbs, "%d")
+			args = append(args, arg)
+		} else {
+			verbs = append(verbs, "%s")
+			args = append(args, "url.PathEscape("+arg+")")
+		}
+	}
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", base+"/"+strings.Join(verbs, "/")+suffix, strings.Join(args, ", "))
+}
+
+// hasClientInclude 详情接口是否支持 include 参数
+func hasClientInclude(model GoModelWrapper) bool {
+	return len(model.Associations) > 0
+}
+
+// buildClientAuth 构建注册、登录和当前用户的客户端方法
+func (g *Generator) buildClientAuth() string {
+	return `package client
+
+import (
+	"context"
+	"net/http"
+
+	"` + g.ModName + `/models"
+)
+
+// Register 注册, 成功后自动使用返回的令牌
+func (c *Client) Register(ctx context.Context, username, password string) (*models.TokenResponse, error) {
+	return c.authenticate(ctx, "/api/v1/auth/register", models.RegisterRequest{Username: username, Password: password})
+}
+
+// Login 登录, 成功后自动使用返回的令牌
+func (c *Client) Login(ctx context.Context, username, password string) (*models.TokenResponse, error) {
+	return c.authenticate(ctx, "/api/v1/auth/login", models.LoginRequest{Username: username, Password: password})
+}
+
+// Me 获取当前登录用户
+func (c *Client) Me(ctx context.Context) (*models.AuthUser, error) {
+	var user models.AuthUser
+	if err := c.do(ctx, http.MethodGet, "/api/v1/auth/me", nil, nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// authenticate 提交注册或登录请求并保存令牌
+func (c *Client) authenticate(ctx context.Context, path string, req any) (*models.TokenResponse, error) {
+	var resp models.TokenResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, req, &resp); err != nil {
+		return nil, err
+	}
+	c.SetToken(resp.Token)
+	return &resp, nil
+}
+`
+}
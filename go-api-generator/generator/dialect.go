@@ -0,0 +1,387 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strings"
+)
+
+// 支持的目标数据库
+const (
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+)
+
+// Dialects 支持的目标数据库列表
+var Dialects = []string{DialectSQLite, DialectPostgres, DialectMySQL}
+
+// dialect 目标数据库方言: 类型映射、驱动依赖和 DDL 语法差异
+type dialect struct {
+	Name       string // 与 GORM Dialector.Name() 一致, 同时作为迁移脚本目录名
+	DriverPath string // GORM 驱动导入路径
+	DriverPkg  string // 驱动包名
+	Require    string // go.mod 依赖
+	DefaultDSN string // 生成项目 -db 参数的默认值, %s 为 module 名
+	DSNHelp    string // -db 参数说明
+}
+
+// dialectRegistry 方言注册表
+var dialectRegistry = map[string]dialect{
+	DialectSQLite: {
+		Name:       DialectSQLite,
+		DriverPath: "github.com/glebarez/sqlite",
+		DriverPkg:  "sqlite",
+		Require:    "github.com/glebarez/sqlite v1.11.0",
+		DefaultDSN: "data.db",
+		DSNHelp:    "SQLite数据库文件路径",
+	},
+	DialectPostgres: {
+		Name:       DialectPostgres,
+		DriverPath: "gorm.io/driver/postgres",
+		DriverPkg:  "postgres",
+		Require:    "gorm.io/driver/postgres v1.5.11",
+		DefaultDSN: "host=localhost user=postgres password=postgres dbname=%s port=5432 sslmode=disable",
+		DSNHelp:    "PostgreSQL连接串, 以 sqlite: 开头或以 .db 结尾时使用内置SQLite",
+	},
+	DialectMySQL: {
+		Name:       DialectMySQL,
+		DriverPath: "gorm.io/driver/mysql",
+		DriverPkg:  "mysql",
+		Require:    "gorm.io/driver/mysql v1.5.7",
+		DefaultDSN: "root:root@tcp(127.0.0.1:3306)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		DSNHelp:    "MySQL连接串, 以 sqlite: 开头或以 .db 结尾时使用内置SQLite",
+	},
+}
+
+// dialect 返回当前目标数据库的方言
+func (g *Generator) dialect() dialect {
+	if d, ok := dialectRegistry[g.Dialect]; ok {
+		return d
+	}
+	return dialectRegistry[DialectSQLite]
+}
+
+// migrationDialects 需要生成迁移脚本的方言: 目标数据库, 非 SQLite 时追加内置 SQLite
+func (g *Generator) migrationDialects() []dialect {
+	d := g.dialect()
+	if d.Name == DialectSQLite {
+		return []dialect{d}
+	}
+	return []dialect{d, dialectRegistry[DialectSQLite]}
+}
+
+// defaultDSN 生成项目 -db 参数的默认值
+func (g *Generator) defaultDSN() string {
+	d := g.dialect()
+	if strings.Contains(d.DefaultDSN, "%s") {
+		return fmt.Sprintf(d.DefaultDSN, strings.ReplaceAll(g.ModName, "-", "_"))
+	}
+	return d.DefaultDSN
+}
+
+// quote 引用标识符, 避免与保留字（如 order）冲突
+func (d dialect) quote(name string) string {
+	if d.Name == DialectMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlType 字段的列类型
+func (d dialect) sqlType(f models.Field) string {
+	switch f.Type {
+	case "string":
+		if f.Length > 0 {
+			return fmt.Sprintf("varchar(%d)", f.Length)
+		}
+		if d.Name == DialectMySQL {
+			// MySQL 的 text 列不能直接建唯一索引
+			return "varchar(255)"
+		}
+		return "text"
+	case "text":
+		return "text"
+	case "enum":
+		return fmt.Sprintf("varchar(%d)", enumLength(f))
+	case "number", "bigint":
+		return d.integerType()
+	case "decimal":
+		if f.IsCents() {
+			return d.integerType()
+		}
+		// SQLite 的 numeric 列会把 "1.50" 转成浮点数, 按文本保存以保留精度和小数位
+		switch d.Name {
+		case DialectPostgres:
+			return fmt.Sprintf("numeric(%d,%d)", f.DecimalPrecision(), f.Scale)
+		case DialectMySQL:
+			return fmt.Sprintf("decimal(%d,%d)", f.DecimalPrecision(), f.Scale)
+		}
+		return "text"
+	case "float":
+		switch d.Name {
+		case DialectPostgres:
+			return "double precision"
+		case DialectMySQL:
+			return "double"
+		}
+		return "real"
+	case "boolean":
+		return "boolean"
+	case "date", "datetime":
+		return d.timeType()
+	case "time":
+		if d.Name == DialectSQLite {
+			return "text"
+		}
+		return "time"
+	case "json":
+		switch d.Name {
+		case DialectPostgres:
+			return "jsonb"
+		case DialectMySQL:
+			return "json"
+		}
+		return "text"
+	case "binary":
+		switch d.Name {
+		case DialectPostgres:
+			return "bytea"
+		case DialectMySQL:
+			return "longblob"
+		}
+		return "blob"
+	default:
+		return "text"
+	}
+}
+
+// integerType 64 位整数列类型
+func (d dialect) integerType() string {
+	if d.Name == DialectSQLite {
+		return "integer"
+	}
+	return "bigint"
+}
+
+// enumLength enum 类型的列长度: 配置了 length 时使用 length, 否则为最长枚举值的长度
+func enumLength(f models.Field) int {
+	if f.Length > 0 {
+		return f.Length
+	}
+	n := 1
+	for _, v := range f.Enum {
+		n = max(n, len(formatValue(v)))
+	}
+	return n
+}
+
+// timeType 时间列类型, created_at/updated_at 也使用该类型
+func (d dialect) timeType() string {
+	switch d.Name {
+	case DialectPostgres:
+		return "timestamptz"
+	case DialectMySQL:
+		return "datetime(3)"
+	}
+	return "datetime"
+}
+
+// versionType 乐观锁版本列的定义, 新记录的版本号为 1
+func (d dialect) versionType() string {
+	return d.integerType() + " NOT NULL DEFAULT 1"
+}
+
+// columnType 构建列定义中字段名之后的部分（类型及约束）
+func (d dialect) columnType(table models.Table, f models.Field) string {
+	return d.columnDef(table, f, true)
+}
+
+// columnDef 构建列定义, withCheck 为 false 时不含枚举 CHECK 约束
+func (d dialect) columnDef(table models.Table, f models.Field, withCheck bool) string {
+	// 复合主键在建表语句末尾以表约束声明, 列定义中只标注单一主键
+	isPK := f.Name == table.PrimaryKey
+	var parts []string
+	switch {
+	case isPK && f.AutoIncrement && d.Name == DialectPostgres:
+		parts = append(parts, "bigserial PRIMARY KEY")
+	case isPK && f.AutoIncrement && d.Name == DialectMySQL:
+		parts = append(parts, d.sqlType(f), "AUTO_INCREMENT PRIMARY KEY")
+	case isPK && f.AutoIncrement:
+		parts = append(parts, d.sqlType(f), "PRIMARY KEY AUTOINCREMENT")
+	case isPK:
+		parts = append(parts, d.sqlType(f), "PRIMARY KEY")
+	default:
+		parts = append(parts, d.sqlType(f))
+	}
+	if f.Required {
+		parts = append(parts, "NOT NULL")
+	}
+	if f.Default != nil {
+		parts = append(parts, "DEFAULT "+formatDefault(f))
+	}
+	if check := d.checkExpr(f); withCheck && check != "" {
+		parts = append(parts, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", d.quote(checkName(table.Name, f.Name)), check))
+	}
+	return strings.Join(parts, " ")
+}
+
+// checkExpr 枚举字段的 CHECK 表达式
+func (d dialect) checkExpr(f models.Field) string {
+	values := enumSQLValues(f)
+	if len(values) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s IN (%s)", d.quote(f.Name), strings.Join(values, ","))
+}
+
+// checkName 枚举 CHECK 约束名, 与 GORM 一致: chk_表名_字段名
+func checkName(table, column string) string {
+	return fmt.Sprintf("chk_%s_%s", table, column)
+}
+
+// tableOptions 建表语句的表选项
+func (d dialect) tableOptions() string {
+	if d.Name == DialectMySQL {
+		return " ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	}
+	return ""
+}
+
+// createIndexSQL 构建单个唯一索引
+func (d dialect) createIndexSQL(table, column string) string {
+	ifNotExists := "IF NOT EXISTS "
+	if d.Name == DialectMySQL {
+		ifNotExists = ""
+	}
+	return fmt.Sprintf("CREATE UNIQUE INDEX %s%s ON %s (%s);",
+		ifNotExists, d.quote(indexName(table, column)), d.quote(table), d.quote(column))
+}
+
+// createSoftDeleteIndexSQL 构建软删除列的普通索引, 索引名与 GORM 一致: idx_表名_deleted_at
+func (d dialect) createSoftDeleteIndexSQL(table string) string {
+	ifNotExists := "IF NOT EXISTS "
+	if d.Name == DialectMySQL {
+		ifNotExists = ""
+	}
+	return fmt.Sprintf("CREATE INDEX %s%s ON %s (%s);",
+		ifNotExists, d.quote(indexName(table, "deleted_at")), d.quote(table), d.quote("deleted_at"))
+}
+
+// dropIndexSQL 构建删除索引语句
+func (d dialect) dropIndexSQL(table, column string) string {
+	if d.Name == DialectMySQL {
+		return fmt.Sprintf("DROP INDEX %s ON %s;", d.quote(indexName(table, column)), d.quote(table))
+	}
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;", d.quote(indexName(table, column)))
+}
+
+// dropCheckSQL 删除枚举 CHECK 约束, checkTable/column 为约束创建时的表名和字段名
+func (d dialect) dropCheckSQL(table, checkTable, column string) string {
+	if d.Name == DialectMySQL {
+		return fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;", d.quote(table), d.quote(checkName(checkTable, column)))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", d.quote(table), d.quote(checkName(checkTable, column)))
+}
+
+// addCheckSQL 添加枚举 CHECK 约束
+func (d dialect) addCheckSQL(table models.Table, f models.Field) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);",
+		d.quote(table.Name), d.quote(checkName(table.Name, f.Name)), d.checkExpr(f))
+}
+
+// foreignKeyDef 外键约束定义, 建表语句的表约束和 ADD CONSTRAINT 共用
+func (d dialect) foreignKeyDef(fk foreignKey) string {
+	def := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.quote(fkName(fk.Table, fk.Column)), d.quote(fk.Column), d.quote(fk.RefTable), d.quote(fk.RefColumn))
+	if fk.Cascade {
+		def += " ON DELETE CASCADE"
+	}
+	return def
+}
+
+// fkName 外键约束名: fk_表名_字段名
+func fkName(table, column string) string {
+	return fmt.Sprintf("fk_%s_%s", table, column)
+}
+
+// addForeignKeySQL 添加外键约束（PostgreSQL / MySQL）, SQLite 的外键只能写在建表语句中
+func (d dialect) addForeignKeySQL(fk foreignKey) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", d.quote(fk.Table), d.foreignKeyDef(fk))
+}
+
+// dropForeignKeySQL 删除外键约束（PostgreSQL / MySQL）
+func (d dialect) dropForeignKeySQL(fk foreignKey) string {
+	if d.Name == DialectMySQL {
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", d.quote(fk.Table), d.quote(fkName(fk.Table, fk.Column)))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", d.quote(fk.Table), d.quote(fkName(fk.Table, fk.Column)))
+}
+
+// alterColumnSQL 修改列定义（PostgreSQL / MySQL）, 变为非空时先用零值填充空值
+// CHECK 约束由调用方在修改前删除、修改后重建
+func (d dialect) alterColumnSQL(table models.Table, old, f models.Field) []string {
+	t, c := d.quote(table.Name), d.quote(f.Name)
+	if table.IsPrimaryKey(f.Name) {
+		return []string{fmt.Sprintf("-- 注意: 主键 %s 定义变化, 请手工编写迁移", f.Name)}
+	}
+
+	var stmts []string
+	if f.Required && !old.Required {
+		stmts = append(stmts, fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IS NULL;", t, c, zeroSQL(f), c))
+	}
+	if d.Name == DialectMySQL {
+		return append(stmts, fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s;", t, c, d.columnDef(table, f, false)))
+	}
+
+	if d.sqlType(old) != d.sqlType(f) {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", t, c, d.sqlType(f), c, d.sqlType(f)))
+	}
+	if f.Required != old.Required {
+		action := "DROP NOT NULL"
+		if f.Required {
+			action = "SET NOT NULL"
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", t, c, action))
+	}
+	if newDefault := defaultSQL(f); newDefault != defaultSQL(old) {
+		action := "DROP DEFAULT"
+		if newDefault != "" {
+			action = "SET DEFAULT " + newDefault
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", t, c, action))
+	}
+	return stmts
+}
+
+// zeroSQL 字段类型零值的 SQL 字面量, 用于给新增的非空列填充历史数据
+func zeroSQL(f models.Field) string {
+	switch {
+	case f.IsInteger() || f.Type == "float":
+		return "0"
+	case f.Type == "boolean":
+		return "FALSE"
+	case f.IsTime():
+		return "CURRENT_TIMESTAMP"
+	case f.Type == "time":
+		return "'00:00:00'"
+	case f.IsDecimalText():
+		return "'0'"
+	case f.Type == "json":
+		return "'null'"
+	case f.Type == "binary":
+		return "''"
+	case f.Type == "enum":
+		return "'" + strings.ReplaceAll(formatValue(f.Enum[0]), "'", "''") + "'"
+	default:
+		return "''"
+	}
+}
+
+// defaultSQL 字段默认值的 SQL 字面量, 无默认值时为空
+func defaultSQL(f models.Field) string {
+	if f.Default == nil {
+		return ""
+	}
+	return formatDefault(f)
+}
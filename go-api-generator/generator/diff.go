@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileChange dry-run 模式下单个文件的变更
+type FileChange struct {
+	Path    string
+	Status  string // 新增, 修改, 未变, 保留
+	Added   int    // 新增行数
+	Removed int    // 删除行数
+}
+
+// recordChange 对比磁盘上的文件和将要写入的内容, 记录变更
+func (g *Generator) recordChange(relPath, content string, userOwned bool) error {
+	change := FileChange{Path: relPath}
+
+	old, err := os.ReadFile(filepath.Join(g.OutputDir, relPath))
+	switch {
+	case os.IsNotExist(err):
+		change.Status = "新增"
+		change.Added = countLines(content)
+	case err != nil:
+		return err
+	case userOwned:
+		change.Status = "保留"
+	case string(old) == content:
+		change.Status = "未变"
+	default:
+		change.Status = "修改"
+		change.Added, change.Removed = diffStat(string(old), content)
+	}
+
+	g.Changes = append(g.Changes, change)
+	return nil
+}
+
+// printChanges 打印 dry-run 的变更汇总
+func (g *Generator) printChanges() {
+	fmt.Println("🔍 dry-run: 以下文件将会变更（未写入磁盘）")
+	counts := make(map[string]int)
+	for _, c := range g.Changes {
+		counts[c.Status]++
+		switch c.Status {
+		case "新增":
+			fmt.Printf("   + %-40s (+%d)\n", c.Path, c.Added)
+		case "修改":
+			fmt.Printf("   ~ %-40s (+%d -%d)\n", c.Path, c.Added, c.Removed)
+		case "保留":
+			fmt.Printf("   = %-40s (用户文件, 保留)\n", c.Path)
+		}
+	}
+	fmt.Printf("   新增 %d, 修改 %d, 未变 %d, 保留 %d\n",
+		counts["新增"], counts["修改"], counts["未变"], counts["保留"])
+}
+
+// countLines 统计文本行数
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	return len(strings.Split(strings.TrimSuffix(s, "\n"), "\n"))
+}
+
+// diffStat 基于最长公共子序列统计新增和删除的行数
+func diffStat(oldText, newText string) (added, removed int) {
+	a := strings.Split(strings.TrimSuffix(oldText, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(newText, "\n"), "\n")
+
+	// 去掉相同的首尾行, 缩小比较范围
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	// 文件过大时不计算 LCS, 直接按整体替换统计
+	if len(a)*len(b) > 4_000_000 {
+		return len(b), len(a)
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else if prev[j] >= curr[j-1] {
+				curr[j] = prev[j]
+			} else {
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	common := prev[len(b)]
+	return len(b) - common, len(a) - common
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strings"
+)
+
+// 生成项目按需引入的依赖
+const (
+	datatypesRequire = "gorm.io/datatypes v1.2.5"
+	validatorRequire = "github.com/go-playground/validator/v10 v10.20.0"
+	excelizeRequire  = "github.com/xuri/excelize/v2 v2.9.0"
+)
+
+// timeOfDayLayout time 类型字段的格式, 与 validator 的 datetime 规则一致
+const timeOfDayLayout = "15:04:05"
+
+// usesField 判断是否有任意模型字段满足条件
+func (g *Generator) usesField(match func(models.Field) bool) bool {
+	for _, model := range g.Models {
+		if hasField(model, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasField 判断模型中是否有字段满足条件
+func hasField(model models.GoModel, match func(models.Field) bool) bool {
+	for _, f := range model.Fields {
+		if match(f.Raw) {
+			return true
+		}
+	}
+	return false
+}
+
+// isJSONField 判断是否为 json 类型字段
+func isJSONField(f models.Field) bool {
+	return f.Type == "json"
+}
+
+// isDecimalText 判断是否为按字符串存储的 decimal 字段, 需要注册 decimal 校验规则
+func isDecimalText(f models.Field) bool {
+	return f.IsDecimalText()
+}
+
+// typeRule 字段类型自带的格式校验规则: time 校验时分秒, 字符串存储的 decimal 校验位数
+func typeRule(field models.Field) string {
+	switch {
+	case field.Type == "time":
+		return "datetime=" + timeOfDayLayout
+	case field.IsDecimalText():
+		return fmt.Sprintf("decimal=%d.%d", field.DecimalPrecision(), field.Scale)
+	}
+	return ""
+}
+
+// modelImports 模型文件的导入列表, 标准库在前, 空字符串表示分组之间的空行
+func modelImports(model models.GoModel, mod string) []string {
+	var std, others []string
+	if model.HasTime {
+		std = append(std, "time")
+	}
+	if uuidKey(model) != nil || model.SoftDelete {
+		others = append(others, "gorm.io/gorm")
+	}
+	if hasField(model, isJSONField) {
+		others = append(others, "gorm.io/datatypes")
+	}
+	if uuidKey(model) != nil {
+		others = append(others, mod+"/utils")
+	}
+	if len(std) > 0 && len(others) > 0 {
+		std = append(std, "")
+	}
+	return append(std, others...)
+}
+
+// decimalPattern 字符串存储的 decimal 的正则, 整数部分最多 precision-scale 位, 小数部分最多 scale 位
+func decimalPattern(f models.Field) string {
+	intDigits := f.DecimalPrecision() - f.Scale
+	intPart := fmt.Sprintf(`\d{1,%d}`, intDigits)
+	if intDigits == 0 {
+		intPart = "0"
+	}
+	if f.Scale == 0 {
+		return "^-?" + intPart + "$"
+	}
+	return fmt.Sprintf(`^-?%s(\.\d{1,%d})?$`, intPart, f.Scale)
+}
+
+// decimalExample 符合位数限制的 decimal 示例值, 如 precision 10、scale 2 时为 12.50
+func decimalExample(f models.Field) string {
+	example := "12"[:min(2, f.DecimalPrecision()-f.Scale)]
+	if example == "" {
+		example = "0"
+	}
+	if f.Scale > 0 {
+		example += ".5" + strings.Repeat("0", f.Scale-1)
+	}
+	return example
+}
+
+// isSearchable 判断字段是否参与关键字搜索: string、text 和 enum
+// time 和 decimal 在 PostgreSQL 中不是文本列, 不能直接 LIKE
+func isSearchable(f models.Field) bool {
+	return f.Type == "string" || f.Type == "text" || f.Type == "enum"
+}
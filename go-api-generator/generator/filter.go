@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strings"
+)
+
+// 列表过滤操作
+const (
+	filterEq   = "eq"   // 精确匹配
+	filterIn   = "in"   // 多值匹配, 参数可重复: ?status_in=1&status_in=2
+	filterMin  = "min"  // 范围下限（含）
+	filterMax  = "max"  // 范围上限（含）
+	filterNull = "null" // 空值判断: true 为 IS NULL, false 为 IS NOT NULL; 非指针字段同时匹配零值
+	filterJSON = "json" // JSON 字段按键路径过滤: ?attrs_key=a.b 判断键存在, 再加 &attrs_value=x 判断键值相等
+
+	filterJSONValue = "json_value" // JSON 过滤的取值参数, 由 filterJSON 条件一并处理
+)
+
+// reservedQueryParams 列表接口的公共查询参数, 同名字段不生成精确匹配过滤
+var reservedQueryParams = map[string]bool{
+	"page": true, "page_size": true, "order_by": true, "order": true, "keyword": true, "include": true,
+	"trashed": true, "cursor": true, "skip_total": true,
+}
+
+// listFilter 列表接口的字段过滤条件, 列名均来自 schema
+type listFilter struct {
+	GoName      string // 查询参数结构体中的字段名
+	Param       string // 查询参数名
+	GoType      string // 查询参数类型
+	Column      string // 过滤的列
+	Op          string // 过滤操作
+	Description string // 参数说明
+	Value       string // filterJSON 中与键路径配对的取值参数字段名
+	Binding     string // 查询参数的校验规则
+	Zero        string // filterNull 中视为空值的零值表达式, 为空时只判断 NULL
+}
+
+// listFilters 根据 schema 推导模型的过滤参数
+// 枚举/布尔字段精确匹配, 整数字段精确匹配和多值匹配, 数字/日期/时间字段范围查询, JSON 字段按键路径查询,
+// 可选字段空值判断（见 nullZero）, 单一主键只支持多值匹配
+func (g *Generator) listFilters(model GoModelWrapper) []listFilter {
+	var filters []listFilter
+	add := func(goName, param, goType, column, op, description string) {
+		filters = append(filters, listFilter{GoName: goName, Param: param, GoType: goType, Column: column, Op: op, Description: description})
+	}
+
+	for _, field := range model.Fields {
+		label := field.Comment
+		if label == "" {
+			label = field.JsonName
+		}
+
+		// 软删除时间由 trashed 参数控制, 版本号只用于并发控制
+		if field.GoName == "DeletedAt" || model.Version && field.GoName == "Version" {
+			continue
+		}
+		// 公共时间字段
+		if field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" {
+			add("Min"+field.GoName, "min_"+field.JsonName, "*time.Time", field.JsonName, filterMin, label+"起始（RFC3339）")
+			add("Max"+field.GoName, "max_"+field.JsonName, "*time.Time", field.JsonName, filterMax, label+"截止（RFC3339）")
+			continue
+		}
+
+		raw := field.Raw
+		baseType := mapGoType(raw)
+		exact := !reservedQueryParams[field.JsonName]
+		switch {
+		case field.GoName == model.PrimaryKey && !isCompositeKey(model):
+			add(field.GoName+"In", field.JsonName+"_in", "[]"+baseType, field.JsonName, filterIn, label+"（多值）")
+		case hasEnum(raw):
+			if exact {
+				add(field.GoName, field.JsonName, "*"+baseType, field.JsonName, filterEq, label)
+			}
+			add(field.GoName+"In", field.JsonName+"_in", "[]"+baseType, field.JsonName, filterIn, label+"（多值）")
+		case raw.Type == "boolean":
+			if exact {
+				add(field.GoName, field.JsonName, "*bool", field.JsonName, filterEq, label)
+			}
+		case raw.IsInteger():
+			if exact {
+				add(field.GoName, field.JsonName, "*int64", field.JsonName, filterEq, label)
+			}
+			add(field.GoName+"In", field.JsonName+"_in", "[]int64", field.JsonName, filterIn, label+"（多值）")
+			add("Min"+field.GoName, "min_"+field.JsonName, "*int64", field.JsonName, filterMin, label+"最小值")
+			add("Max"+field.GoName, "max_"+field.JsonName, "*int64", field.JsonName, filterMax, label+"最大值")
+		case raw.Type == "float":
+			add("Min"+field.GoName, "min_"+field.JsonName, "*float64", field.JsonName, filterMin, label+"最小值")
+			add("Max"+field.GoName, "max_"+field.JsonName, "*float64", field.JsonName, filterMax, label+"最大值")
+		case raw.IsTime():
+			add("Min"+field.GoName, "min_"+field.JsonName, "*time.Time", field.JsonName, filterMin, label+"起始（RFC3339）")
+			add("Max"+field.GoName, "max_"+field.JsonName, "*time.Time", field.JsonName, filterMax, label+"截止（RFC3339）")
+		case raw.Type == "time", raw.IsDecimalText():
+			// time 为 HH:MM:SS 定长格式, 按字符串比较与按时间比较一致;
+			// decimal 在 SQLite 中以文本存储, 转换为数值后比较
+			column, from, to := field.JsonName, "起始（HH:MM:SS）", "截止（HH:MM:SS）"
+			if raw.IsDecimalText() {
+				column = fmt.Sprintf("CAST(%s AS DECIMAL(%d,%d))", field.JsonName, raw.DecimalPrecision(), raw.Scale)
+				from, to = "最小值", "最大值"
+			}
+			add("Min"+field.GoName, "min_"+field.JsonName, "*string", column, filterMin, label+from)
+			add("Max"+field.GoName, "max_"+field.JsonName, "*string", column, filterMax, label+to)
+			filters[len(filters)-2].Binding = "omitempty," + typeRule(raw)
+			filters[len(filters)-1].Binding = "omitempty," + typeRule(raw)
+		case raw.Type == "json":
+			add(field.GoName+"Key", field.JsonName+"_key", "*string", field.JsonName, filterJSON, label+"中存在的键路径, 用 . 分隔")
+			filters[len(filters)-1].Value = field.GoName + "Value"
+			add(field.GoName+"Value", field.JsonName+"_value", "*string", field.JsonName, filterJSONValue, label+"中键路径对应的值, 需同时指定 "+field.JsonName+"_key")
+		}
+
+		if zero, ok := nullZero(field); ok && !raw.Required {
+			description := label + "是否为空"
+			switch zero {
+			case `""`:
+				description += "（NULL 或空字符串）"
+			case "time.Time{}":
+				description += "（NULL 或零值时间）"
+			}
+			add(field.GoName+"Null", field.JsonName+"_null", "*bool", field.JsonName, filterNull, description)
+			filters[len(filters)-1].Zero = zero
+		}
+	}
+	return filters
+}
+
+// nullZero 可选字段空值判断时与 NULL 同等对待的零值, ok 为 false 时不生成空值判断
+// 指针、JSON 和二进制字段未填写时写入 NULL, 只判断 NULL; 字符串和时间字段未填写时写入零值, 零值也视为空;
+// 非指针的数字和布尔字段未填写时写入 0 / false, 这是有意义的取值, 不生成空值判断
+func nullZero(field models.GoField) (zero string, ok bool) {
+	switch {
+	case strings.HasPrefix(field.GoType, "*"), field.GoType == "datatypes.JSON", field.GoType == "[]byte":
+		return "", true
+	case field.GoType == "string":
+		return `""`, true
+	case field.GoType == "time.Time":
+		return "time.Time{}", true
+	}
+	return "", false
+}
+
+// filterSQLOp 精确匹配和范围过滤对应的 SQL 比较运算符
+func filterSQLOp(op string) string {
+	return map[string]string{filterEq: "=", filterMin: ">=", filterMax: "<="}[op]
+}
+
+// filterSchemaType 过滤参数在 OpenAPI 中的类型
+func filterSchemaType(f listFilter) map[string]any {
+	base := strings.TrimLeft(f.GoType, "*[]")
+	var schema map[string]any
+	switch base {
+	case "int64":
+		schema = map[string]any{"type": "integer", "format": "int64"}
+	case "float64":
+		schema = map[string]any{"type": "number", "format": "double"}
+	case "bool":
+		schema = map[string]any{"type": "boolean"}
+	case "time.Time":
+		schema = map[string]any{"type": "string", "format": "date-time"}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	if strings.HasPrefix(f.GoType, "[]") {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
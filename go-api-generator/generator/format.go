@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formatGoSource 格式化生成的 Go 代码: 删除未使用的导入, 再按 gofmt 规则排版和排序导入
+// 代码无法解析时返回带文件名和行号的错误, 避免模板问题生成无法编译的文件
+func formatGoSource(relPath, src string) (string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, relPath, src, parser.ParseComments)
+	if err != nil {
+		return "", syntaxError(src, err)
+	}
+
+	src = removeUnusedImports(fset, file, src)
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", relPath, err)
+	}
+	return string(formatted), nil
+}
+
+// syntaxError 在解析错误后附上出错的源码行
+func syntaxError(src string, err error) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return err
+	}
+	first := list[0]
+	lines := strings.Split(src, "\n")
+	if first.Pos.Line < 1 || first.Pos.Line > len(lines) {
+		return first
+	}
+	return fmt.Errorf("%w\n\t> %s", first, strings.TrimSpace(lines[first.Pos.Line-1]))
+}
+
+// removeUnusedImports 删除文件中未被引用的导入, 匿名导入和点导入保留
+// 直接在源码上删除对应行, 避免修改 AST 后位置信息错乱
+func removeUnusedImports(fset *token.FileSet, file *ast.File, src string) string {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		var unused []ast.Spec
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			name := importPathToName(importPath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if name != "_" && name != "." && !used[name] {
+				unused = append(unused, spec)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+		// 整个 import 声明都未使用时删除声明本身
+		if len(unused) == len(gen.Specs) {
+			spans = append(spans, span{fset.Position(gen.Pos()).Offset, fset.Position(gen.End()).Offset})
+			continue
+		}
+		for _, spec := range unused {
+			spans = append(spans, span{fset.Position(spec.Pos()).Offset, fset.Position(spec.End()).Offset})
+		}
+	}
+
+	// 从后往前删除, 连同所在行的缩进和换行
+	for i := len(spans) - 1; i >= 0; i-- {
+		start := strings.LastIndex(src[:spans[i].start], "\n") + 1
+		end := spans[i].end
+		if j := strings.IndexByte(src[end:], '\n'); j >= 0 {
+			end += j + 1
+		}
+		src = src[:start] + src[end:]
+	}
+	return src
+}
+
+// importPathToName 根据导入路径推断包名, 规则与 goimports 一致:
+// 去掉 /vN 版本后缀和 go- 前缀, 截断到第一个非标识符字符, 如 gopkg.in/yaml.v3 -> yaml
+func importPathToName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
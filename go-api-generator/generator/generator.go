@@ -5,17 +5,30 @@ import (
 	"go-api-generator/models"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/template"
 	"unicode"
 )
 
+// generatedHeader 生成的 Go 文件头, 标识文件会在重新生成时被覆盖
+const generatedHeader = "// Code generated by go-api-generator. DO NOT EDIT.\n\n"
+
 // Generator 代码生成器
 type Generator struct {
 	Config    *models.SchemaConfig
 	OutputDir string
 	ModName   string // 生成项目的 Go module 名称
-	Models    []models.GoModel
-	Relations []models.GoRelation
+	DryRun    bool   // 只计算文件变更, 不写入磁盘
+	Dialect   string // 目标数据库: sqlite / postgres / mysql
+	// PrevConfig 上一版本的配置, 用于生成迁移; 为空时读取输出目录中的 schema 快照
+	PrevConfig *models.SchemaConfig
+	Models     []models.GoModel
+	Relations  []models.GoRelation
+	Changes    []FileChange // dry-run 模式下记录的文件变更
+	// TemplateDir 自定义模板目录, 其中的同名 .tmpl 文件覆盖内置模板
+	TemplateDir string
+	templates   *template.Template
 }
 
 // NewGenerator 创建代码生成器
@@ -24,49 +37,58 @@ func NewGenerator(config *models.SchemaConfig, outputDir, modName string) *Gener
 		Config:    config,
 		OutputDir: outputDir,
 		ModName:   modName,
+		Dialect:   DialectSQLite,
 	}
 }
 
 // Generate 执行完整的代码生成流程
 func (g *Generator) Generate() error {
-	fmt.Println("🚀 开始生成项目代码...")
+	if _, ok := dialectRegistry[g.Dialect]; !ok {
+		return fmt.Errorf("不支持的数据库类型: %s（可选: %s）", g.Dialect, strings.Join(Dialects, " / "))
+	}
+	fmt.Printf("🚀 开始生成项目代码（数据库: %s）...\n", g.Dialect)
+	if err := g.loadTemplates(); err != nil {
+		return fmt.Errorf("加载模板失败: %w", err)
+	}
 
 	// 第1步: 转换数据模型
-	fmt.Println("  [1/7] 转换数据模型...")
+	fmt.Println("  [1/9] 转换数据模型...")
 	g.transformModels()
 
 	// 第2步: 创建目录结构
-	fmt.Println("  [2/7] 创建目录结构...")
-	if err := g.createDirectories(); err != nil {
-		return fmt.Errorf("创建目录失败: %w", err)
+	fmt.Println("  [2/9] 创建目录结构...")
+	if !g.DryRun {
+		if err := g.createDirectories(); err != nil {
+			return fmt.Errorf("创建目录失败: %w", err)
+		}
 	}
 
 	// 第3步: 生成 go.mod
-	fmt.Println("  [3/7] 生成 go.mod...")
+	fmt.Println("  [3/9] 生成 go.mod...")
 	if err := g.generateGoMod(); err != nil {
 		return fmt.Errorf("生成 go.mod 失败: %w", err)
 	}
 
 	// 第4步: 生成模型层代码
-	fmt.Println("  [4/7] 生成模型层代码...")
+	fmt.Println("  [4/9] 生成模型层代码...")
 	if err := g.generateModels(); err != nil {
 		return fmt.Errorf("生成模型层失败: %w", err)
 	}
 
 	// 第5步: 生成数据库层代码
-	fmt.Println("  [5/7] 生成数据库层代码...")
+	fmt.Println("  [5/9] 生成数据库层代码...")
 	if err := g.generateDatabase(); err != nil {
 		return fmt.Errorf("生成数据库层失败: %w", err)
 	}
 
 	// 第6步: 生成处理器层代码
-	fmt.Println("  [6/7] 生成处理器层代码...")
+	fmt.Println("  [6/9] 生成处理器层代码...")
 	if err := g.generateHandlers(); err != nil {
 		return fmt.Errorf("生成处理器层失败: %w", err)
 	}
 
 	// 第7步: 生成路由和主入口
-	fmt.Println("  [7/7] 生成路由和主入口...")
+	fmt.Println("  [7/9] 生成路由和主入口...")
 	if err := g.generateRouter(); err != nil {
 		return fmt.Errorf("生成路由失败: %w", err)
 	}
@@ -74,6 +96,23 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("生成主入口失败: %w", err)
 	}
 
+	// 第8步: 生成 OpenAPI 文档
+	fmt.Println("  [8/9] 生成 OpenAPI 文档...")
+	if err := g.generateOpenAPI(); err != nil {
+		return fmt.Errorf("生成 OpenAPI 文档失败: %w", err)
+	}
+
+	// 第9步: 生成客户端 SDK
+	fmt.Println("  [9/9] 生成客户端 SDK...")
+	if err := g.generateClient(); err != nil {
+		return fmt.Errorf("生成客户端失败: %w", err)
+	}
+
+	if g.DryRun {
+		g.printChanges()
+		return nil
+	}
+
 	fmt.Println("✅ 代码生成完成！")
 	fmt.Printf("   输出目录: %s\n", g.OutputDir)
 	fmt.Println("   启动方式:")
@@ -90,7 +129,12 @@ func (g *Generator) transformModels() {
 			Name:        ToPascalCase(table.Name),
 			TableName:   table.Name,
 			Description: table.Description,
-			PrimaryKey:  ToPascalCase(table.PrimaryKey),
+		}
+		for _, key := range table.PrimaryKeys() {
+			goModel.PrimaryKeys = append(goModel.PrimaryKeys, ToPascalCase(key))
+		}
+		if len(goModel.PrimaryKeys) > 0 {
+			goModel.PrimaryKey = goModel.PrimaryKeys[0]
 		}
 
 		for _, field := range table.Fields {
@@ -98,9 +142,19 @@ func (g *Generator) transformModels() {
 				GoName:   ToPascalCase(field.Name),
 				JsonName: field.Name,
 				GoType:   mapGoType(field),
-				GormTag:  buildGormTag(field, table.PrimaryKey),
+				GormTag:  buildGormTag(g.dialect(), field, table),
 				JsonTag:  field.Name,
 				Comment:  field.Comment,
+				Raw:      field,
+			}
+			// bigint 在 JSON 中使用字符串, 避免 JavaScript 丢失 2^53 以上的精度
+			if field.Type == "bigint" {
+				goField.JsonTag += ",string"
+			}
+			// 默认值非零值时使用指针, 避免 GORM 用默认值覆盖客户端显式传入的零值
+			// 可选外键同样使用指针: 未填写时写入 NULL, 零值会违反外键约束
+			if needsPointer(field) || (!field.Required && g.isForeignKey(table.Name, field.Name)) {
+				goField.GoType = "*" + goField.GoType
 			}
 
 			if goField.GoType == "time.Time" {
@@ -116,23 +170,47 @@ func (g *Generator) transformModels() {
 		// 添加公共字段: created_at, updated_at
 		goModel.Fields = append(goModel.Fields,
 			models.GoField{
-				GoName:  "CreatedAt",
+				GoName:   "CreatedAt",
 				JsonName: "created_at",
-				GoType:  "time.Time",
-				GormTag: "autoCreateTime",
-				JsonTag: "created_at",
-				Comment: "创建时间",
+				GoType:   "time.Time",
+				GormTag:  "autoCreateTime",
+				JsonTag:  "created_at",
+				Comment:  "创建时间",
 			},
 			models.GoField{
-				GoName:  "UpdatedAt",
+				GoName:   "UpdatedAt",
 				JsonName: "updated_at",
-				GoType:  "time.Time",
-				GormTag: "autoUpdateTime",
-				JsonTag: "updated_at",
-				Comment: "更新时间",
+				GoType:   "time.Time",
+				GormTag:  "autoUpdateTime",
+				JsonTag:  "updated_at",
+				Comment:  "更新时间",
 			},
 		)
 		goModel.HasTime = true
+		// 软删除: gorm.DeletedAt 使 Delete 只设置 deleted_at, 查询自动排除已删除记录
+		if table.SoftDelete {
+			goModel.Fields = append(goModel.Fields, models.GoField{
+				GoName:   "DeletedAt",
+				JsonName: "deleted_at",
+				GoType:   "gorm.DeletedAt",
+				GormTag:  "index",
+				JsonTag:  "deleted_at",
+				Comment:  "删除时间",
+			})
+			goModel.SoftDelete = true
+		}
+		// 乐观锁: 版本号从 1 开始, 每次更新加 1, 接口中用作 ETag
+		if table.Version {
+			goModel.Fields = append(goModel.Fields, models.GoField{
+				GoName:   "Version",
+				JsonName: "version",
+				GoType:   "int64",
+				GormTag:  "column:version;not null;default:1",
+				JsonTag:  "version",
+				Comment:  "版本号",
+			})
+			goModel.Version = true
+		}
 
 		g.Models = append(g.Models, goModel)
 	}
@@ -147,6 +225,212 @@ func (g *Generator) transformModels() {
 			ReferenceKey: ToPascalCase(rel.ReferenceKey),
 		})
 	}
+
+	// 根据关系生成关联字段
+	g.buildAssociations()
+}
+
+// buildAssociations 将表关系转换为模型上的关联字段
+// 关系中 from 表持有外键: one-to-one 生成 has one / belongs to,
+// one-to-many 生成 has many / belongs to, many-to-many 中 from 为中间表,
+// 同一中间表的两条关系合并为两侧的 many2many 字段
+func (g *Generator) buildAssociations() {
+	joinRelations := make(map[string][]models.Relation)
+	var joinTables []string
+
+	for _, rel := range g.Config.Relations {
+		from, to := g.findModel(rel.From), g.findModel(rel.To)
+		if from == nil || to == nil {
+			continue
+		}
+		fk := findField(*from, rel.ForeignKey)
+		if fk == nil {
+			fmt.Printf("   ⚠️  关系 %s -> %s 的外键 %s 不在表 %s 中, 已跳过\n", rel.From, rel.To, rel.ForeignKey, rel.From)
+			continue
+		}
+		ref := g.referenceKey(*to, rel.ReferenceKey)
+		refColumn := fieldColumn(*to, ref)
+		keyType := strings.TrimPrefix(fk.GoType, "*")
+
+		if rel.Type == "many-to-many" {
+			if _, ok := joinRelations[rel.From]; !ok {
+				joinTables = append(joinTables, rel.From)
+			}
+			joinRelations[rel.From] = append(joinRelations[rel.From], rel)
+			continue
+		}
+
+		// 外键去掉 _id 后缀作为关联前缀, 例如 assignee_id -> assignee
+		base := strings.TrimSuffix(rel.ForeignKey, "_id")
+		if base == rel.ForeignKey {
+			base = rel.To
+		}
+
+		// from 表: belongs to
+		from.Associations = append(from.Associations, models.GoAssociation{
+			GoName:          ToPascalCase(uniqueAssocName(*from, base)),
+			JsonName:        uniqueAssocName(*from, base),
+			Kind:            "belongs-to",
+			Model:           to.Name,
+			TableName:       to.TableName,
+			Description:     to.Description,
+			ForeignKey:      fk.GoName,
+			ForeignColumn:   fk.JsonName,
+			ReferenceKey:    ref,
+			ReferenceColumn: refColumn,
+			KeyType:         keyType,
+		})
+
+		// to 表: has one / has many
+		name := rel.From
+		if base != rel.To {
+			name = base + "_" + rel.From
+		}
+		kind := "has-one"
+		if rel.Type == "one-to-many" {
+			kind = "has-many"
+			name = Pluralize(name)
+		}
+		name = uniqueAssocName(*to, name)
+		to.Associations = append(to.Associations, models.GoAssociation{
+			GoName:          ToPascalCase(name),
+			JsonName:        name,
+			Kind:            kind,
+			Model:           from.Name,
+			TableName:       from.TableName,
+			Description:     from.Description,
+			ForeignKey:      fk.GoName,
+			ForeignColumn:   fk.JsonName,
+			ReferenceKey:    ref,
+			ReferenceColumn: refColumn,
+			KeyType:         keyType,
+		})
+	}
+
+	// 多对多: 每个中间表需要恰好两条关系
+	for _, joinTable := range joinTables {
+		rels := joinRelations[joinTable]
+		if len(rels) != 2 {
+			fmt.Printf("   ⚠️  中间表 %s 需要恰好两条 many-to-many 关系, 实际 %d 条, 已跳过\n", joinTable, len(rels))
+			continue
+		}
+		join := g.findModel(joinTable)
+		for i, rel := range rels {
+			other := rels[1-i]
+			owner, target := g.findModel(rel.To), g.findModel(other.To)
+			name := uniqueAssocName(*owner, Pluralize(other.To))
+			ref := g.referenceKey(*target, other.ReferenceKey)
+			owner.Associations = append(owner.Associations, models.GoAssociation{
+				GoName:          ToPascalCase(name),
+				JsonName:        name,
+				Kind:            "many2many",
+				Model:           target.Name,
+				TableName:       target.TableName,
+				Description:     target.Description,
+				ReferenceKey:    ref,
+				ReferenceColumn: fieldColumn(*target, ref),
+				KeyType:         fieldKeyType(*target, ref),
+				JoinTable:       join.TableName,
+				JoinModel:       join.Name,
+				JoinForeign:     ToPascalCase(rel.ForeignKey),
+				JoinReference:   ToPascalCase(other.ForeignKey),
+			})
+		}
+	}
+}
+
+// findModel 根据表名查找模型
+func (g *Generator) findModel(tableName string) *models.GoModel {
+	for i := range g.Models {
+		if g.Models[i].TableName == tableName {
+			return &g.Models[i]
+		}
+	}
+	return nil
+}
+
+// referenceKey 返回关系引用字段的 Go 名称, 未指定时使用目标表主键
+func (g *Generator) referenceKey(model models.GoModel, referenceKey string) string {
+	if referenceKey != "" {
+		return ToPascalCase(referenceKey)
+	}
+	return pkGoName(model)
+}
+
+// isForeignKey 字段是否为关系中的外键
+func (g *Generator) isForeignKey(table, field string) bool {
+	for _, rel := range g.Config.Relations {
+		if rel.From == table && rel.ForeignKey == field {
+			return true
+		}
+	}
+	return false
+}
+
+// findField 根据原始字段名查找字段
+func findField(model models.GoModel, name string) *models.GoField {
+	for i := range model.Fields {
+		if model.Fields[i].JsonName == name {
+			return &model.Fields[i]
+		}
+	}
+	return nil
+}
+
+// uniqueAssocName 避免关联名与已有字段或关联重名
+func uniqueAssocName(model models.GoModel, name string) string {
+	taken := func(n string) bool {
+		if findField(model, n) != nil {
+			return true
+		}
+		for _, a := range model.Associations {
+			if a.JsonName == n {
+				return true
+			}
+		}
+		return false
+	}
+	if !taken(name) {
+		return name
+	}
+	candidate := name + "_rel"
+	for i := 2; taken(candidate); i++ {
+		candidate = fmt.Sprintf("%s_rel%d", name, i)
+	}
+	return candidate
+}
+
+// pkGoName 返回模型主键的 Go 名称, 未配置时默认为 ID
+func pkGoName(model models.GoModel) string {
+	if model.PrimaryKey != "" {
+		return model.PrimaryKey
+	}
+	return "ID"
+}
+
+// pkColumn 返回模型主键列名（复合主键时为第一个字段）, 默认为 id
+func pkColumn(model models.GoModel) string {
+	return fieldColumn(model, pkGoName(model))
+}
+
+// fieldColumn 根据 Go 字段名返回列名, 未找到时默认为 id
+func fieldColumn(model models.GoModel, goName string) string {
+	for _, f := range model.Fields {
+		if f.GoName == goName {
+			return f.JsonName
+		}
+	}
+	return "id"
+}
+
+// fieldKeyType 根据 Go 字段名返回作为主键/外键使用时的 Go 类型（去掉指针）, 未找到时默认为 int64
+func fieldKeyType(model models.GoModel, goName string) string {
+	for _, f := range model.Fields {
+		if f.GoName == goName {
+			return strings.TrimPrefix(f.GoType, "*")
+		}
+	}
+	return "int64"
 }
 
 // createDirectories 创建输出目录结构
@@ -154,8 +438,10 @@ func (g *Generator) createDirectories() error {
 	dirs := []string{
 		filepath.Join(g.OutputDir, "models"),
 		filepath.Join(g.OutputDir, "database"),
+		filepath.Join(g.OutputDir, "migrations"),
 		filepath.Join(g.OutputDir, "handlers"),
 		filepath.Join(g.OutputDir, "router"),
+		filepath.Join(g.OutputDir, "client"),
 		filepath.Join(g.OutputDir, "middleware"),
 		filepath.Join(g.OutputDir, "utils"),
 	}
@@ -167,9 +453,20 @@ func (g *Generator) createDirectories() error {
 	return nil
 }
 
-// writeFile 辅助方法: 写入文件
+// writeFile 辅助方法: 写入生成的文件, 已存在时覆盖
+// Go 文件会加上 DO NOT EDIT 文件头并格式化, 自定义逻辑应写在 *_hooks.go 等用户文件中
 func (g *Generator) writeFile(relPath, content string) error {
+	if strings.HasSuffix(relPath, ".go") {
+		formatted, err := formatGoSource(relPath, generatedHeader+content)
+		if err != nil {
+			return err
+		}
+		content = formatted
+	}
 	fullPath := filepath.Join(g.OutputDir, relPath)
+	if g.DryRun {
+		return g.recordChange(relPath, content, false)
+	}
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
@@ -177,6 +474,30 @@ func (g *Generator) writeFile(relPath, content string) error {
 	return os.WriteFile(fullPath, []byte(content), 0644)
 }
 
+// writeUserFile 写入用户文件, 仅在文件不存在时创建, 重新生成时保留用户修改
+func (g *Generator) writeUserFile(relPath, content string) error {
+	if strings.HasSuffix(relPath, ".go") {
+		formatted, err := formatGoSource(relPath, content)
+		if err != nil {
+			return err
+		}
+		content = formatted
+	}
+	fullPath := filepath.Join(g.OutputDir, relPath)
+	if g.DryRun {
+		return g.recordChange(relPath, content, true)
+	}
+	if _, err := os.Stat(fullPath); err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, []byte(content), 0644)
+}
+
 // ===================== 工具函数 =====================
 
 // ToPascalCase 将 snake_case 转换为 PascalCase
@@ -207,6 +528,19 @@ func ToPascalCase(s string) string {
 	return result.String()
 }
 
+// Pluralize 将 snake_case 名称的最后一个单词转换为复数形式
+func Pluralize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	default:
+		return s + "s"
+	}
+}
+
 // ToCamelCase 将 snake_case 转换为 camelCase
 func ToCamelCase(s string) string {
 	pascal := ToPascalCase(s)
@@ -234,47 +568,35 @@ func ToCamelCase(s string) string {
 }
 
 // mapGoType 将配置中的类型映射为 Go 类型
+// decimal 按 storage 映射为 string 或 int64, time（时分秒）为 string
 func mapGoType(field models.Field) string {
-	switch field.Type {
-	case "number":
+	switch {
+	case field.IsInteger():
 		return "int64"
-	case "float":
+	case field.Type == "float":
 		return "float64"
-	case "string":
-		return "string"
-	case "text":
-		return "string"
-	case "boolean":
+	case field.Type == "boolean":
 		return "bool"
-	case "date":
+	case field.IsTime():
 		return "time.Time"
+	case field.Type == "json":
+		return "datatypes.JSON"
+	case field.Type == "binary":
+		return "[]byte"
 	default:
 		return "string"
 	}
 }
 
-// buildGormTag 构建 GORM 标签
-func buildGormTag(field models.Field, primaryKey string) string {
+// buildGormTag 构建 GORM 标签, 列类型按目标数据库映射; 复合主键的每个字段都带 primaryKey
+func buildGormTag(d dialect, field models.Field, table models.Table) string {
 	var parts []string
 
-	if field.Name == primaryKey {
+	if table.IsPrimaryKey(field.Name) {
 		parts = append(parts, "primaryKey")
 	}
 	parts = append(parts, fmt.Sprintf("column:%s", field.Name))
-
-	if field.Type == "string" && field.Length > 0 {
-		parts = append(parts, fmt.Sprintf("type:varchar(%d)", field.Length))
-	} else if field.Type == "text" {
-		parts = append(parts, "type:text")
-	} else if field.Type == "number" {
-		parts = append(parts, "type:integer")
-	} else if field.Type == "float" {
-		parts = append(parts, "type:real")
-	} else if field.Type == "boolean" {
-		parts = append(parts, "type:boolean")
-	} else if field.Type == "date" {
-		parts = append(parts, "type:datetime")
-	}
+	parts = append(parts, fmt.Sprintf("type:%s", d.sqlType(field)))
 
 	if field.AutoIncrement {
 		parts = append(parts, "autoIncrement")
@@ -285,6 +607,12 @@ func buildGormTag(field models.Field, primaryKey string) string {
 	if field.Required {
 		parts = append(parts, "not null")
 	}
+	if field.Default != nil {
+		parts = append(parts, fmt.Sprintf("default:%s", formatDefault(field)))
+	}
+	if values := enumSQLValues(field); len(values) > 0 {
+		parts = append(parts, fmt.Sprintf("check:%s IN (%s)", field.Name, strings.Join(values, ",")))
+	}
 	if field.Comment != "" {
 		parts = append(parts, fmt.Sprintf("comment:%s", field.Comment))
 	}
@@ -293,10 +621,12 @@ func buildGormTag(field models.Field, primaryKey string) string {
 }
 
 // buildValidateTag 构建验证标签
+// 有默认值或枚举包含零值的字段不加 required, 缺省时分别由默认值和 oneof 兜底
 func buildValidateTag(field models.Field) string {
 	var parts []string
 
-	if field.Required && !field.AutoIncrement {
+	required := field.Required && !field.AutoIncrement && field.Default == nil && !enumHasZero(field)
+	if required {
 		parts = append(parts, "required")
 	}
 	if field.Format == "email" {
@@ -308,14 +638,142 @@ func buildValidateTag(field models.Field) string {
 	if field.Format == "uuid" {
 		parts = append(parts, "uuid")
 	}
-	if field.Length > 0 && field.Type == "string" {
+	if field.Length > 0 {
 		parts = append(parts, fmt.Sprintf("max=%d", field.Length))
 	}
+	if rule := typeRule(field); rule != "" {
+		parts = append(parts, rule)
+	}
+	if oneOf := buildOneOf(field); oneOf != "" {
+		parts = append(parts, oneOf)
+	}
 
 	if len(parts) == 0 {
 		return ""
 	}
+	if !required {
+		parts = append([]string{"omitempty"}, parts...)
+	}
 	return strings.Join(parts, ",")
 }
 
+// hasEnum 判断字段是否配置了可用的枚举值（仅数字、字符串和 enum 类型）
+func hasEnum(field models.Field) bool {
+	switch field.Type {
+	case "number", "float", "string", "text", "enum":
+		return len(field.Enum) > 0
+	}
+	return false
+}
 
+// enumHasZero 判断枚举值中是否包含该类型的零值
+func enumHasZero(field models.Field) bool {
+	if !hasEnum(field) {
+		return false
+	}
+	for _, v := range field.Enum {
+		if formatValue(v) == formatValue(zeroValue(field)) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsPointer 判断字段是否需要使用指针类型
+// GORM 创建记录时会用 default 标签替换零值, 当零值本身合法时需要指针区分“未传”和“零值”
+func needsPointer(field models.Field) bool {
+	if field.Default == nil || formatValue(field.Default) == formatValue(zeroValue(field)) {
+		return false
+	}
+	switch {
+	case field.Type == "boolean":
+		return true
+	case field.IsInteger() || field.Type == "float":
+		return !hasEnum(field) || enumHasZero(field)
+	}
+	return false
+}
+
+// zeroValue 返回字段类型的零值
+func zeroValue(field models.Field) any {
+	switch {
+	case field.IsInteger() || field.Type == "float":
+		return 0
+	case field.Type == "boolean":
+		return false
+	default:
+		return ""
+	}
+}
+
+// formatValue 将 JSON 中的值格式化为字面量文本（数字不使用科学计数法）
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// formatDefault 格式化 GORM default 标签的值
+func formatDefault(field models.Field) string {
+	switch {
+	case field.IsString():
+		return "'" + strings.ReplaceAll(formatValue(field.Default), "'", "''") + "'"
+	default:
+		return formatValue(field.Default)
+	}
+}
+
+// enumSQLValues 返回枚举值的 SQL 字面量, 用于 CHECK 约束
+func enumSQLValues(field models.Field) []string {
+	if !hasEnum(field) {
+		return nil
+	}
+	values := make([]string, len(field.Enum))
+	for i, v := range field.Enum {
+		if field.IsString() {
+			values[i] = "'" + strings.ReplaceAll(formatValue(v), "'", "''") + "'"
+		} else {
+			values[i] = formatValue(v)
+		}
+	}
+	return values
+}
+
+// buildOneOf 构建枚举字段的 oneof 验证规则
+func buildOneOf(field models.Field) string {
+	if !hasEnum(field) {
+		return ""
+	}
+	values := make([]string, len(field.Enum))
+	for i, v := range field.Enum {
+		values[i] = formatValue(v)
+		if strings.ContainsAny(values[i], " ") {
+			values[i] = "'" + values[i] + "'"
+		}
+	}
+	return "oneof=" + strings.Join(values, " ")
+}
+
+// enumConstName 构建枚举常量名, 例如 OrderStatus1、TaskTypeFeature
+func enumConstName(model, field string, value any, index int) string {
+	text := strings.ReplaceAll(formatValue(value), ".", "_")
+	if _, isNumber := value.(float64); isNumber && strings.HasPrefix(text, "-") {
+		text = "neg_" + text[1:]
+	}
+	var sb strings.Builder
+	for _, r := range ToPascalCase(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	suffix := sb.String()
+	if suffix == "" {
+		suffix = strconv.Itoa(index)
+	}
+	return model + ToPascalCase(field) + suffix
+}
@@ -0,0 +1,359 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"go-api-generator/config"
+	"go-api-generator/models"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// update 重新生成 golden 文件: go test ./generator -run TestGolden -update
+var update = flag.Bool("update", false, "用当前生成结果覆盖 golden 文件")
+
+// goldenFileMarker golden 文件中每个生成文件的分隔行格式
+const goldenFileMarker = "-- %s --\n"
+
+// exampleConfigs 返回 examples 目录下的所有配置, 键为示例名
+func exampleConfigs(t *testing.T) map[string]string {
+	t.Helper()
+	var paths []string
+	for _, pattern := range []string{"*.json", "*.yaml", "*.yml", "*.sql"} {
+		matches, err := filepath.Glob(filepath.Join("..", "examples", pattern))
+		if err != nil {
+			t.Fatalf("查找示例配置失败: %v", err)
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		t.Fatal("未找到示例配置")
+	}
+	examples := make(map[string]string, len(paths))
+	for _, path := range paths {
+		base := filepath.Base(path)
+		examples[strings.TrimSuffix(base, filepath.Ext(base))] = path
+	}
+	return examples
+}
+
+// generateExample 将示例配置生成到临时目录, module 名与示例名相同
+func generateExample(t *testing.T, name, path string) string {
+	t.Helper()
+	cfg, err := config.NewParser().ParseFile(path)
+	if err != nil {
+		t.Fatalf("解析 %s 失败: %v", path, err)
+	}
+	out := t.TempDir()
+	if err := NewGenerator(cfg, out, name).Generate(); err != nil {
+		t.Fatalf("生成 %s 失败: %v", name, err)
+	}
+	return out
+}
+
+// readTree 读取目录下的所有文件, 键为以 / 分隔的相对路径
+// Swagger UI 静态资源体积大且原样复制, 只记录大小和 SHA-256
+func readTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(rel, swaggerAssetDir+"/") {
+			data = []byte(fmt.Sprintf("%d 字节, sha256 %x\n", len(data), sha256.Sum256(data)))
+		}
+		files[rel] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("读取生成结果失败: %v", err)
+	}
+	return files
+}
+
+// encodeGolden 将文件集合编码为单个 golden 文件, 按路径排序
+func encodeGolden(files map[string]string) []byte {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	for _, path := range paths {
+		fmt.Fprintf(&buf, goldenFileMarker, path)
+		buf.WriteString(files[path])
+		if !strings.HasSuffix(files[path], "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes()
+}
+
+// decodeGolden 解析 golden 文件
+func decodeGolden(data []byte) map[string]string {
+	files := make(map[string]string)
+	var current string
+	var content strings.Builder
+	flush := func() {
+		if current != "" {
+			files[current] = content.String()
+		}
+		content.Reset()
+	}
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if strings.HasPrefix(line, "-- ") && strings.HasSuffix(line, " --\n") {
+			flush()
+			current = strings.TrimSuffix(strings.TrimPrefix(line, "-- "), " --\n")
+			continue
+		}
+		content.WriteString(line)
+	}
+	flush()
+	return files
+}
+
+// firstDiff 返回两段文本第一处不同的行号和内容
+func firstDiff(got, want string) (int, string, string) {
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return i + 1, g, w
+		}
+	}
+	return 0, "", ""
+}
+
+// TestGolden 对每个示例配置生成项目, 与 testdata/golden 下的 golden 文件逐文件比较
+func TestGolden(t *testing.T) {
+	for name, path := range exampleConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			files := readTree(t, generateExample(t, name, path))
+			golden := filepath.Join("testdata", "golden", name+".golden")
+
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, encodeGolden(files), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			data, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("读取 golden 文件失败（使用 -update 生成）: %v", err)
+			}
+			got, want := decodeGolden(encodeGolden(files)), decodeGolden(data)
+			for path := range want {
+				if _, ok := got[path]; !ok {
+					t.Errorf("缺少文件 %s", path)
+				}
+			}
+			for path, content := range got {
+				expected, ok := want[path]
+				if !ok {
+					t.Errorf("多出文件 %s", path)
+					continue
+				}
+				if line, g, w := firstDiff(content, expected); line > 0 {
+					t.Errorf("%s:%d 与 golden 不一致\n  生成: %s\n  期望: %s", path, line, g, w)
+				}
+			}
+		})
+	}
+}
+
+// TestGeneratedCodeVet 对每个示例的生成结果执行 go vet, 依赖只从本地模块缓存读取
+// 模块缓存中缺少依赖或使用 -short 时跳过
+func TestGeneratedCodeVet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("-short 模式跳过 go vet 检查")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("未找到 go 命令")
+	}
+
+	for name, path := range exampleConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			out := generateExample(t, name, path)
+			env := append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod", "GOWORK=off")
+
+			tidy := exec.Command("go", "mod", "tidy")
+			tidy.Dir, tidy.Env = out, env
+			if output, err := tidy.CombinedOutput(); err != nil {
+				t.Skipf("依赖不在本地模块缓存中, 跳过: %s", firstLine(output))
+			}
+
+			vet := exec.Command("go", "vet", "./...")
+			vet.Dir, vet.Env = out, env
+			if output, err := vet.CombinedOutput(); err != nil {
+				t.Fatalf("go vet 失败:\n%s", output)
+			}
+		})
+	}
+}
+
+// firstLine 命令输出的第一行
+func firstLine(output []byte) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return line
+}
+
+// TestTemplateOverride 自定义模板目录只覆盖同名模板, 其余文件仍使用内置模板
+func TestTemplateOverride(t *testing.T) {
+	const name = "01_single_todo"
+	path := filepath.Join("..", "examples", name+".json")
+	cfg, err := config.NewParser().ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	builtin, err := defaultTemplates.ReadFile("templates/handler.go.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := strings.Replace(string(builtin), "package handlers\n", "package handlers\n\n// custom handler template\n", 1)
+	if err := os.WriteFile(filepath.Join(dir, "handler.go.tmpl"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewGenerator(cfg, t.TempDir(), name)
+	gen.TemplateDir = dir
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("生成失败: %v", err)
+	}
+	want := readTree(t, generateExample(t, name, path))
+	for path, content := range readTree(t, gen.OutputDir) {
+		overridden := strings.Contains(content, "// custom handler template")
+		if path == "handlers/todo_handler.go" {
+			if !overridden {
+				t.Errorf("%s 未使用自定义模板", path)
+			}
+			continue
+		}
+		if content != want[path] {
+			t.Errorf("%s 不应受自定义模板影响", path)
+		}
+	}
+
+	// 未知模板名报错, 避免文件名拼写错误时静默使用内置模板
+	if err := os.WriteFile(filepath.Join(dir, "handlers.go.tmpl"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gen = NewGenerator(cfg, t.TempDir(), name)
+	gen.TemplateDir = dir
+	if err := gen.Generate(); err == nil || !strings.Contains(err.Error(), "未知模板") {
+		t.Errorf("未知模板应报错, 实际: %v", err)
+	}
+}
+
+// TestFormatGoSource 生成的代码会被格式化并删除未使用的导入, 语法错误报告文件名和行号
+func TestFormatGoSource(t *testing.T) {
+	src := `package demo
+
+import (
+	"strings"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	_ "embed"
+	"os"
+)
+
+type T struct {
+	A string ` + "`json:\"a\"`" + `
+	LongName int ` + "`json:\"long_name\"`" + `
+}
+
+func f() (jwt.Claims, string) { return nil, fmt.Sprint(1) }
+`
+	got, err := formatGoSource("demo/demo.go", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, removed := range []string{`"strings"`, `"os"`} {
+		if strings.Contains(got, removed) {
+			t.Errorf("未使用的导入 %s 未删除:\n%s", removed, got)
+		}
+	}
+	for _, kept := range []string{`"fmt"`, `"github.com/golang-jwt/jwt/v5"`, `_ "embed"`, "A        string `json:\"a\"`"} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("缺少 %s:\n%s", kept, got)
+		}
+	}
+
+	_, err = formatGoSource("demo/bad.go", "package demo\n\nfunc f() {\n\treturn 1 +\n}\n")
+	if err == nil || !strings.Contains(err.Error(), "demo/bad.go:5:") {
+		t.Errorf("语法错误应包含文件名和行号, 实际: %v", err)
+	}
+}
+
+// TestMigrationForeignKeys 初始迁移包含关系对应的外键约束, 关系增删时迁移添加或删除约束
+func TestMigrationForeignKeys(t *testing.T) {
+	path := filepath.Join("..", "examples", "07_one2many_shop_order.json")
+	parse := func() *models.SchemaConfig {
+		cfg, err := config.NewParser().ParseFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+	withFK, withoutFK := parse(), parse()
+	withoutFK.Relations = slices.DeleteFunc(withoutFK.Relations, func(rel models.Relation) bool {
+		return rel.From == "order" && rel.ForeignKey == "customer_id"
+	})
+
+	sqlite, postgres := dialectRegistry[DialectSQLite], dialectRegistry[DialectPostgres]
+	constraint := `CONSTRAINT "fk_order_customer_id" FOREIGN KEY ("customer_id") REFERENCES "customer" ("id")`
+	tests := []struct {
+		name    string
+		stmts   []string
+		want    string
+		notWant string
+	}{
+		{"SQLite 建表时声明外键", sqlite.createSchemaSQL(withFK), constraint, ""},
+		{"PostgreSQL 建表后添加外键", postgres.createSchemaSQL(withFK), `ALTER TABLE "order" ADD ` + constraint + ";", ""},
+		{"PostgreSQL 删表前删除外键", postgres.dropSchemaSQL(withFK), `ALTER TABLE "order" DROP CONSTRAINT IF EXISTS "fk_order_customer_id";`, ""},
+		{"SQLite 新增关系时重建表", sqlite.diffSchemas(withoutFK, withFK, collectRenames(withFK)), constraint, ""},
+		{"SQLite 删除关系时重建表", sqlite.diffSchemas(withFK, withoutFK, collectRenames(withoutFK)), `CREATE TABLE "order__new"`, constraint},
+		{"PostgreSQL 新增关系时添加外键", postgres.diffSchemas(withoutFK, withFK, collectRenames(withFK)), `ALTER TABLE "order" ADD ` + constraint + ";", ""},
+		{"PostgreSQL 删除关系时删除外键", postgres.diffSchemas(withFK, withoutFK, collectRenames(withoutFK)), `ALTER TABLE "order" DROP CONSTRAINT IF EXISTS "fk_order_customer_id";`, "CREATE TABLE"},
+		{"关系不变时没有迁移", sqlite.diffSchemas(withFK, parse(), collectRenames(withFK)), "", ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(tt.stmts, "\n")
+		if tt.want == "" && got != "" {
+			t.Errorf("%s: 期望没有迁移语句, 实际:\n%s", tt.name, got)
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("%s: 缺少 %s\n%s", tt.name, tt.want, got)
+		}
+		if tt.notWant != "" && strings.Contains(got, tt.notWant) {
+			t.Errorf("%s: 不应包含 %s\n%s", tt.name, tt.notWant, got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"strings"
+)
+
+// missingUUID 测试中不存在的 UUID 主键
+const missingUUID = "00000000-0000-4000-8000-999999999999"
+
+// isCompositeKey 判断模型是否使用复合主键
+func isCompositeKey(model models.GoModel) bool {
+	return len(model.PrimaryKeys) > 1
+}
+
+// keyFields 返回主键字段, 复合主键按配置顺序; 未配置主键时为 ID 字段
+func keyFields(model models.GoModel) []models.GoField {
+	names := model.PrimaryKeys
+	if len(names) == 0 {
+		names = []string{pkGoName(model)}
+	}
+	var fields []models.GoField
+	for _, name := range names {
+		for _, f := range model.Fields {
+			if f.GoName == name {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		// 没有主键字段时与 GORM 默认一致, 使用整数 ID
+		fields = append(fields, models.GoField{GoName: "ID", JsonName: "id", GoType: "int64"})
+	}
+	return fields
+}
+
+// isKeyField 判断字段是否为主键（或复合主键的一部分）
+func isKeyField(model models.GoModel, field models.GoField) bool {
+	for _, f := range keyFields(model) {
+		if f.GoName == field.GoName {
+			return true
+		}
+	}
+	return false
+}
+
+// keyType 主键在 handlers/database 包中的 Go 类型, 复合主键为 models.XxxKey
+func keyType(model models.GoModel) string {
+	if isCompositeKey(model) {
+		return "models." + model.Name + "Key"
+	}
+	return fieldKeyType(model, pkGoName(model))
+}
+
+// keyWhere 按主键查询的 Where 参数, 如 "id = ?", id 或 "user_id = ? AND role_id = ?", id.UserID, id.RoleID
+func keyWhere(model models.GoModel, name string) string {
+	if !isCompositeKey(model) {
+		return fmt.Sprintf("%q, %s", pkColumn(model)+" = ?", name)
+	}
+	var conditions, args []string
+	for _, f := range keyFields(model) {
+		conditions = append(conditions, f.JsonName+" = ?")
+		args = append(args, name+"."+f.GoName)
+	}
+	return fmt.Sprintf("%q, %s", strings.Join(conditions, " AND "), strings.Join(args, ", "))
+}
+
+// keyPath 单条记录的路由路径, 复合主键每个字段一段, 如 /:user_id/:role_id
+// 单一主键固定使用 :id, 与嵌套路由 /:id/xxx 的参数名一致
+func keyPath(model models.GoModel) string {
+	if !isCompositeKey(model) {
+		return "/:id"
+	}
+	var sb strings.Builder
+	for _, f := range keyFields(model) {
+		sb.WriteString("/:" + f.JsonName)
+	}
+	return sb.String()
+}
+
+// isUUIDKey 判断字段是否为创建时自动生成 UUID 的主键: 单一主键、string 类型且格式为 uuid
+func isUUIDKey(model models.GoModel, field models.GoField) bool {
+	return !isCompositeKey(model) && field.GoName == pkGoName(model) &&
+		field.Raw.Type == "string" && field.Raw.Format == "uuid"
+}
+
+// uuidKey 返回自动生成 UUID 的主键字段, 没有时返回 nil
+func uuidKey(model models.GoModel) *models.GoField {
+	for i, f := range model.Fields {
+		if isUUIDKey(model, f) {
+			return &model.Fields[i]
+		}
+	}
+	return nil
+}
+
+// pathParser 解析路径参数的函数名（生成在 handlers/params.go 中）
+func pathParser(field models.GoField) string {
+	switch {
+	case strings.TrimPrefix(field.GoType, "*") == "int64":
+		return "pathInt64"
+	case field.Raw.Format == "uuid":
+		return "pathUUID"
+	default:
+		return "pathString"
+	}
+}
+
+// fieldParser 按 Go 字段名查找字段并返回其路径参数解析函数, 用于嵌套路由中按外键类型解析 :id
+func fieldParser(model models.GoModel, goName string) string {
+	for _, f := range model.Fields {
+		if f.GoName == goName {
+			return pathParser(f)
+		}
+	}
+	return "pathInt64"
+}
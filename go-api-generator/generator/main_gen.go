@@ -5,9 +5,30 @@ import (
 )
 
 // generateGoMod 生成 go.mod 文件
-// 策略：只声明三个直接依赖，间接依赖交给 go mod tidy 自动解析
+// 策略：只声明直接依赖，间接依赖交给 go mod tidy 自动解析
 // 这样可以彻底避免 pseudo-version 锁定失效的问题（如 chenzhuoyu/base64x）
+// 非 SQLite 项目额外依赖对应驱动, SQLite 驱动始终保留用于本地开发和测试
+// 启用认证时额外依赖 JWT 和 bcrypt; 有 json 字段时依赖 gorm.io/datatypes, 有字符串 decimal 字段时依赖 validator
+// Excel 导入导出依赖 excelize
 func (g *Generator) generateGoMod() error {
+	deps := ""
+	if g.usesField(isDecimalText) {
+		deps += "\t" + validatorRequire + "\n"
+	}
+	if g.authEnabled() {
+		deps += "\tgithub.com/golang-jwt/jwt/v5 v5.2.1\n"
+	}
+	deps += "\t" + excelizeRequire + "\n"
+	if g.authEnabled() {
+		deps += "\tgolang.org/x/crypto v0.31.0\n"
+	}
+	gormDeps := ""
+	if g.usesField(isJSONField) {
+		gormDeps += "\t" + datatypesRequire + "\n"
+	}
+	if d := g.dialect(); d.Name != DialectSQLite {
+		gormDeps += "\t" + d.Require + "\n"
+	}
 	content := fmt.Sprintf(`module %s
 
 go 1.22
@@ -15,9 +36,9 @@ go 1.22
 require (
 	github.com/gin-gonic/gin v1.10.0
 	github.com/glebarez/sqlite v1.11.0
-	gorm.io/gorm v1.25.12
+%s%s	gorm.io/gorm v1.25.12
 )
-`, g.ModName)
+`, g.ModName, deps, gormDeps)
 
 	return g.writeFile("go.mod", content)
 }
@@ -27,35 +48,66 @@ func (g *Generator) generateMain() error {
 	content := fmt.Sprintf(`package main
 
 import (
+	_ "embed"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 
 	"%s/database"
+	"%s/handlers"
 	"%s/router"
 )
 
+// openapiSpec 生成的 OpenAPI 文档
+//
+//go:embed openapi.json
+var openapiSpec []byte
+
 func main() {
 	// 命令行参数
 	port := flag.String("port", "8080", "服务端口")
-	dbPath := flag.String("db", "data.db", "SQLite数据库文件路径")
+	dbPath := flag.String("db", envOr("DATABASE_URL", %q), "%s（默认读取环境变量 DATABASE_URL）")
 	flag.Parse()
 
+	// 迁移子命令: go run main.go -db data.db migrate up|down [N]|status
+	if flag.Arg(0) == "migrate" {
+		runMigrate(*dbPath, flag.Args()[1:])
+		return
+	}
+
 	// 初始化数据库
 	if err := database.InitDB(*dbPath); err != nil {
 		log.Fatalf("数据库初始化失败: %%v", err)
 	}
+`, g.ModName, g.ModName, g.ModName, g.defaultDSN(), g.dialect().DSNHelp)
+
+	if g.authEnabled() {
+		content += `
+	// 初始管理员: 设置 ADMIN_PASSWORD 后, 用户不存在时自动创建
+	if err := handlers.EnsureAdmin(envOr("ADMIN_USERNAME", "admin"), os.Getenv("ADMIN_PASSWORD")); err != nil {
+		log.Fatalf("创建管理员失败: %v", err)
+	}
+`
+	}
 
+	content += `
 	// 配置路由
+	handlers.SetOpenAPISpec(openapiSpec)
 	r := router.SetupRouter()
 
 	// 启动服务
-	addr := fmt.Sprintf(":%%s", *port)
-	log.Printf("🚀 服务启动成功，监听地址: http://localhost:%%s", *port)
-	log.Printf("📋 健康检查: http://localhost:%%s/health", *port)
-	log.Printf("📖 API基础路径: http://localhost:%%s/api/v1", *port)
+	addr := fmt.Sprintf(":%s", *port)
+	log.Printf("🚀 服务启动成功，监听地址: http://localhost:%s", *port)
+	log.Printf("📋 健康检查: http://localhost:%s/health", *port)
+	log.Printf("📖 API基础路径: http://localhost:%s/api/v1", *port)
+	log.Printf("📚 API文档: http://localhost:%s/swagger", *port)
 	log.Println("========================================")
-`, g.ModName, g.ModName)
+`
+	if g.authEnabled() {
+		content += "\tlog.Println(\"  🔐 认证: /api/v1/auth/register, /api/v1/auth/login, /api/v1/auth/me\")\n"
+	}
 
 	// 打印路由信息
 	for _, model := range g.Models {
@@ -70,6 +122,62 @@ func main() {
 		log.Fatalf("服务启动失败: %%v", err)
 	}
 }
+
+// envOr 读取环境变量, 未设置时返回默认值
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// runMigrate 执行迁移子命令
+func runMigrate(dbPath string, args []string) {
+	if err := database.Connect(dbPath); err != nil {
+		log.Fatalf("数据库连接失败: %%v", err)
+	}
+
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	switch action {
+	case "up":
+		if err := database.MigrateUp(); err != nil {
+			log.Fatalf("%%v", err)
+		}
+		log.Println("✅ 迁移完成")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatalf("回滚步数无效: %%s", args[1])
+			}
+			steps = n
+		}
+		if err := database.MigrateDown(steps); err != nil {
+			log.Fatalf("%%v", err)
+		}
+		log.Printf("✅ 已回滚 %%d 个迁移", steps)
+	case "status":
+		statuses, err := database.GetMigrationStatus()
+		if err != nil {
+			log.Fatalf("%%v", err)
+		}
+		for _, s := range statuses {
+			state := "未应用"
+			if s.Applied {
+				state = "已应用 " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%%04d_%%-30s %%s\n", s.Version, s.Name, state)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "用法: migrate up | down [N] | status")
+		os.Exit(2)
+	}
+}
 `)
 
 	// 生成 utils
@@ -0,0 +1,972 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-api-generator/models"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// migrationFilePattern 迁移文件名格式: 0001_init.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d{4})_.+\.up\.sql$`)
+
+// schemaRenames 重命名映射, 键为目标 schema 中的名称, 值为源 schema 中的名称
+type schemaRenames struct {
+	tables map[string]string            // 表名
+	fields map[string]map[string]string // 表名(目标) -> 字段名
+}
+
+// generateMigrations 生成版本化迁移脚本、迁移执行器和 schema 快照
+// 上一版本配置优先取 -prev 参数, 否则取输出目录中的 migrations/schema.json 快照
+// 每种方言的迁移脚本放在 migrations/{方言}/ 下, 非 SQLite 项目额外生成内置 SQLite 的脚本
+// 启用认证时用户表与业务表一起参与迁移
+func (g *Generator) generateMigrations() error {
+	prev := g.PrevConfig
+	if prev == nil {
+		var err error
+		if prev, err = loadSnapshot(filepath.Join(g.OutputDir, "migrations", "schema.json")); err != nil {
+			return err
+		}
+	}
+
+	prev, current := migrationSchema(prev), migrationSchema(g.Config)
+	for _, d := range g.migrationDialects() {
+		if err := g.generateDialectMigrations(d, prev, current); err != nil {
+			return err
+		}
+	}
+
+	snapshot, err := json.MarshalIndent(g.Config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化 schema 快照失败: %w", err)
+	}
+	if err := g.writeFile("migrations/schema.json", string(snapshot)+"\n"); err != nil {
+		return err
+	}
+	if err := g.writeFile("migrations/migrations.go", g.buildMigrationsEmbed()); err != nil {
+		return err
+	}
+	return g.writeFile("database/migrate.go", g.buildMigrationRunner())
+}
+
+// generateDialectMigrations 生成单个方言的迁移脚本
+func (g *Generator) generateDialectMigrations(d dialect, prev, current *models.SchemaConfig) error {
+	count, next, err := scanMigrations(filepath.Join(g.OutputDir, "migrations", d.Name))
+	if err != nil {
+		return fmt.Errorf("读取已有迁移失败: %w", err)
+	}
+
+	if count == 0 && prev != nil {
+		// 已有数据库但还没有迁移: 先以上一版本作为初始迁移
+		if err := g.writeMigration(d, next, "init", prev.Version, d.createSchemaSQL(prev), d.dropSchemaSQL(prev)); err != nil {
+			return err
+		}
+		count, next = 1, next+1
+	}
+
+	switch {
+	case count == 0:
+		return g.writeMigration(d, next, "init", current.Version, d.createSchemaSQL(current), d.dropSchemaSQL(current))
+	case prev == nil:
+		fmt.Printf("   ⚠️  %s 已有迁移但缺少上一版本配置, 请使用 -prev 指定旧配置, 本次不生成迁移\n", d.Name)
+		return nil
+	}
+
+	renames := collectRenames(current)
+	up := d.diffSchemas(prev, current, renames)
+	if len(up) == 0 {
+		fmt.Printf("   ℹ️  %s 表结构无变化, 不生成迁移\n", d.Name)
+		return nil
+	}
+	if prev.Version == current.Version {
+		fmt.Printf("   ⚠️  表结构已变化但 version 仍为 %s, 建议更新版本号\n", current.Version)
+	}
+	down := d.diffSchemas(current, prev, invertRenames(renames))
+	return g.writeMigration(d, next, "v"+sanitizeVersion(current.Version), prev.Version, up, down)
+}
+
+// writeMigration 写入一对 up/down 迁移文件, 已存在的迁移文件不会被覆盖
+func (g *Generator) writeMigration(d dialect, number int, name, fromVersion string, up, down []string) error {
+	base := fmt.Sprintf("migrations/%s/%04d_%s", d.Name, number, name)
+	header := fmt.Sprintf("-- %04d_%s: 由 go-api-generator 生成 (%s, %s -> %s)\n\n", number, name, d.Name, fromVersion, g.Config.Version)
+	if name == "init" {
+		header = fmt.Sprintf("-- %04d_%s: 由 go-api-generator 生成 (%s, version %s)\n\n", number, name, d.Name, fromVersion)
+	}
+	if err := g.writeUserFile(base+".up.sql", header+strings.Join(up, "\n\n")+"\n"); err != nil {
+		return err
+	}
+	fmt.Printf("   📝 迁移: %s.up.sql / .down.sql\n", base)
+	return g.writeUserFile(base+".down.sql", header+strings.Join(down, "\n\n")+"\n")
+}
+
+// scanMigrations 统计已有迁移数量并返回下一个编号
+func scanMigrations(dir string) (count, next int, err error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, 1, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	max := 0
+	for _, e := range entries {
+		m := migrationFilePattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		count++
+		if n, _ := strconv.Atoi(m[1]); n > max {
+			max = n
+		}
+	}
+	return count, max + 1, nil
+}
+
+// loadSnapshot 读取上一次生成时保存的 schema 快照, 不存在时返回 nil
+func loadSnapshot(path string) (*models.SchemaConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取 schema 快照失败: %w", err)
+	}
+	var config models.SchemaConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("解析 schema 快照失败: %w", err)
+	}
+	return &config, nil
+}
+
+// sanitizeVersion 将版本号转换为文件名片段, 例如 1.1 -> 1_1
+func sanitizeVersion(version string) string {
+	var sb strings.Builder
+	for _, r := range version {
+		if r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
+
+// collectRenames 从配置的 renamedFrom 收集重命名映射
+func collectRenames(config *models.SchemaConfig) schemaRenames {
+	renames := schemaRenames{tables: map[string]string{}, fields: map[string]map[string]string{}}
+	for _, t := range config.Tables {
+		if t.RenamedFrom != "" {
+			renames.tables[t.Name] = t.RenamedFrom
+		}
+		for _, f := range t.Fields {
+			if f.RenamedFrom == "" {
+				continue
+			}
+			if renames.fields[t.Name] == nil {
+				renames.fields[t.Name] = map[string]string{}
+			}
+			renames.fields[t.Name][f.Name] = f.RenamedFrom
+		}
+	}
+	return renames
+}
+
+// invertRenames 反转重命名映射, 用于生成 down 迁移
+func invertRenames(renames schemaRenames) schemaRenames {
+	inverted := schemaRenames{tables: map[string]string{}, fields: map[string]map[string]string{}}
+	for newName, oldName := range renames.tables {
+		inverted.tables[oldName] = newName
+	}
+	for table, fields := range renames.fields {
+		oldTable := table
+		if t, ok := renames.tables[table]; ok {
+			oldTable = t
+		}
+		inverted.fields[oldTable] = map[string]string{}
+		for newName, oldName := range fields {
+			inverted.fields[oldTable][oldName] = newName
+		}
+	}
+	return inverted
+}
+
+// ===================== DDL 构建 =====================
+
+// createSchemaSQL 构建所有表的建表语句
+// SQLite 的外键写在建表语句中; PostgreSQL/MySQL 要求引用的表已存在, 在所有表创建后再添加外键
+func (d dialect) createSchemaSQL(config *models.SchemaConfig) []string {
+	var stmts []string
+	for _, t := range config.Tables {
+		stmts = append(stmts, d.createTableSQL(t, t.Name, true, foreignKeys(config, t.Name)))
+		stmts = append(stmts, d.createIndexesSQL(t)...)
+	}
+	if d.Name != DialectSQLite {
+		for _, fk := range foreignKeys(config, "") {
+			stmts = append(stmts, d.addForeignKeySQL(fk))
+		}
+	}
+	return stmts
+}
+
+// dropSchemaSQL 构建删除所有表的语句（逆序）, PostgreSQL/MySQL 先删除外键, 不依赖表的顺序
+func (d dialect) dropSchemaSQL(config *models.SchemaConfig) []string {
+	var stmts []string
+	if d.Name != DialectSQLite {
+		for _, fk := range foreignKeys(config, "") {
+			stmts = append(stmts, d.dropForeignKeySQL(fk))
+		}
+	}
+	for i := len(config.Tables) - 1; i >= 0; i-- {
+		stmts = append(stmts, fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.quote(config.Tables[i].Name)))
+	}
+	return stmts
+}
+
+// foreignKey 关系对应的外键约束: Table.Column 引用 RefTable.RefColumn
+type foreignKey struct {
+	Table, Column       string
+	RefTable, RefColumn string
+	Cascade             bool // 多对多中间表的记录随引用的记录一起删除
+}
+
+// foreignKeys 返回 table 表持有的外键约束（table 为空时返回所有表的）, 按关系顺序
+// 每条关系的 from 表持有外键, 引用 to 表的 referenceKey, 未指定时为 to 表主键
+func foreignKeys(config *models.SchemaConfig, table string) []foreignKey {
+	pks := make(map[string]string)
+	for _, t := range config.Tables {
+		pks[t.Name] = t.PrimaryKey
+	}
+	var fks []foreignKey
+	for _, rel := range config.Relations {
+		if table != "" && rel.From != table {
+			continue
+		}
+		ref := rel.ReferenceKey
+		if ref == "" {
+			ref = pks[rel.To]
+		}
+		fks = append(fks, foreignKey{
+			Table:     rel.From,
+			Column:    rel.ForeignKey,
+			RefTable:  rel.To,
+			RefColumn: ref,
+			Cascade:   rel.Type == "many-to-many",
+		})
+	}
+	return fks
+}
+
+// createTableSQL 构建建表语句, name 可与表名不同（重建表时使用临时表名）
+// fks 只在 SQLite 中作为表约束写入, 其他数据库由调用方在建表后添加
+func (d dialect) createTableSQL(table models.Table, name string, ifNotExists bool, fks []foreignKey) string {
+	var columns []string
+	for _, f := range table.Fields {
+		columns = append(columns, fmt.Sprintf("%s %s", d.quote(f.Name), d.columnType(table, f)))
+	}
+	columns = append(columns,
+		fmt.Sprintf("%s %s", d.quote("created_at"), d.timeType()),
+		fmt.Sprintf("%s %s", d.quote("updated_at"), d.timeType()))
+	if table.SoftDelete {
+		columns = append(columns, fmt.Sprintf("%s %s", d.quote("deleted_at"), d.timeType()))
+	}
+	if table.Version {
+		columns = append(columns, fmt.Sprintf("%s %s", d.quote("version"), d.versionType()))
+	}
+	if keys := table.PrimaryKeys(); len(keys) > 1 {
+		// 复合主键不能写在列定义中, 作为表约束声明
+		quoted := make([]string, len(keys))
+		for i, k := range keys {
+			quoted[i] = d.quote(k)
+		}
+		columns = append(columns, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(quoted, ", ")))
+	}
+	if d.Name == DialectSQLite {
+		for _, fk := range fks {
+			columns = append(columns, d.foreignKeyDef(fk))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE ")
+	if ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	sb.WriteString(d.quote(name) + " (\n    ")
+	sb.WriteString(strings.Join(columns, ",\n    "))
+	sb.WriteString("\n)" + d.tableOptions() + ";")
+	return sb.String()
+}
+
+// createIndexesSQL 构建唯一索引, 索引名与 GORM 一致: idx_表名_字段名
+func (d dialect) createIndexesSQL(table models.Table) []string {
+	var stmts []string
+	for _, f := range table.Fields {
+		if f.Unique {
+			stmts = append(stmts, d.createIndexSQL(table.Name, f.Name))
+		}
+	}
+	if table.SoftDelete {
+		stmts = append(stmts, d.createSoftDeleteIndexSQL(table.Name))
+	}
+	return stmts
+}
+
+// indexName 唯一索引名
+func indexName(table, column string) string {
+	return fmt.Sprintf("idx_%s_%s", table, column)
+}
+
+// ===================== Schema 对比 =====================
+
+// diffSchemas 对比两个版本的配置, 生成从 from 变为 to 的 SQL 语句
+// PostgreSQL/MySQL 先删除变化的外键, 表结构变更完成后再添加; SQLite 的外键变化通过重建表完成
+func (d dialect) diffSchemas(from, to *models.SchemaConfig, renames schemaRenames) []string {
+	var stmts []string
+	droppedFKs, addedFKs := diffForeignKeys(from, to, renames)
+	if d.Name != DialectSQLite {
+		for _, fk := range droppedFKs {
+			stmts = append(stmts, d.dropForeignKeySQL(fk))
+		}
+	}
+
+	fromTables := make(map[string]models.Table)
+	for _, t := range from.Tables {
+		fromTables[t.Name] = t
+	}
+	matched := make(map[string]string) // 旧表名 -> 新表名
+
+	for _, t := range to.Tables {
+		oldName := t.Name
+		if r, ok := renames.tables[t.Name]; ok {
+			oldName = r
+		}
+		if _, ok := fromTables[oldName]; ok {
+			matched[oldName] = t.Name
+		}
+	}
+	fkChanged := make(map[string]bool) // 外键变化的表（新表名）
+	for _, fk := range addedFKs {
+		fkChanged[fk.Table] = true
+	}
+	for _, fk := range droppedFKs {
+		fkChanged[matched[fk.Table]] = true
+	}
+
+	for _, t := range to.Tables {
+		oldName := t.Name
+		if r, ok := renames.tables[t.Name]; ok {
+			oldName = r
+		}
+		old, ok := fromTables[oldName]
+		if !ok {
+			stmts = append(stmts, d.createTableSQL(t, t.Name, false, foreignKeys(to, t.Name)))
+			stmts = append(stmts, d.createIndexesSQL(t)...)
+			continue
+		}
+		stmts = append(stmts, d.diffTable(old, t, renames.fields[t.Name], foreignKeys(to, t.Name), fkChanged[t.Name])...)
+	}
+
+	for _, t := range from.Tables {
+		if _, ok := matched[t.Name]; !ok {
+			stmts = append(stmts, fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.quote(t.Name)))
+		}
+	}
+	if d.Name != DialectSQLite {
+		for _, fk := range addedFKs {
+			stmts = append(stmts, d.addForeignKeySQL(fk))
+		}
+	}
+	return stmts
+}
+
+// diffForeignKeys 对比两个版本的外键, 返回要删除的旧外键（旧表名）和要添加的新外键
+// 按重命名对应后引用关系不变, 且约束名中的表名、列名也不变的外键保持不动
+func diffForeignKeys(from, to *models.SchemaConfig, renames schemaRenames) (dropped, added []foreignKey) {
+	oldName := func(table string) string {
+		if r, ok := renames.tables[table]; ok {
+			return r
+		}
+		return table
+	}
+	oldColumn := func(table, column string) string {
+		if r, ok := renames.fields[table][column]; ok {
+			return r
+		}
+		return column
+	}
+
+	existing := make(map[foreignKey]bool)
+	for _, fk := range foreignKeys(from, "") {
+		existing[fk] = true
+	}
+	kept := make(map[foreignKey]bool)
+	for _, fk := range foreignKeys(to, "") {
+		old := foreignKey{
+			Table:     oldName(fk.Table),
+			Column:    oldColumn(fk.Table, fk.Column),
+			RefTable:  oldName(fk.RefTable),
+			RefColumn: oldColumn(fk.RefTable, fk.RefColumn),
+			Cascade:   fk.Cascade,
+		}
+		if existing[old] && old.Table == fk.Table && old.Column == fk.Column {
+			kept[old] = true
+			continue
+		}
+		added = append(added, fk)
+	}
+	for _, fk := range foreignKeys(from, "") {
+		if !kept[fk] {
+			dropped = append(dropped, fk)
+		}
+	}
+	return dropped, added
+}
+
+// columnPair 新旧版本中对应的同一列
+type columnPair struct {
+	old, new models.Field
+}
+
+// diffTable 对比单个表, 能用 ALTER TABLE 完成的变更直接生成
+// SQLite 无法修改列定义和外键, 通过重建表完成, fks 为重建后的外键; PostgreSQL/MySQL 使用 ALTER COLUMN / MODIFY COLUMN
+func (d dialect) diffTable(old, table models.Table, fieldRenames map[string]string, fks []foreignKey, fkChanged bool) []string {
+	oldFields := make(map[string]models.Field)
+	for _, f := range old.Fields {
+		oldFields[f.Name] = f
+	}
+	matched := make(map[string]bool)
+	sources := make(map[string]string) // 新字段名 -> 旧字段名
+
+	rebuild := fkChanged
+	var pairs []columnPair
+	var added, dropped []models.Field
+	for _, f := range table.Fields {
+		oldName := f.Name
+		if r, ok := fieldRenames[f.Name]; ok {
+			oldName = r
+		}
+		of, ok := oldFields[oldName]
+		if !ok {
+			added = append(added, f)
+			if !d.canAddColumn(table, f) {
+				rebuild = true
+			}
+			continue
+		}
+		matched[oldName] = true
+		sources[f.Name] = oldName
+		pairs = append(pairs, columnPair{of, f})
+		if columnModified(d, old, table, of, f) || enumModified(of, f) {
+			rebuild = true
+		}
+	}
+	for _, f := range old.Fields {
+		if !matched[f.Name] {
+			dropped = append(dropped, f)
+			if old.IsPrimaryKey(f.Name) {
+				rebuild = true
+			}
+		}
+	}
+	keysChanged := compositeKeyModified(old, table, fieldRenames)
+	if keysChanged {
+		rebuild = true
+	}
+
+	var stmts []string
+	renamed := old.Name != table.Name
+
+	// 约束和索引名带表名/列名, 表或列改名、列定义变化时先删除, 最后按新名称重建
+	if d.Name != DialectSQLite {
+		for _, p := range pairs {
+			if d.checkExpr(p.old) != "" && d.recreateCheck(old, table, p) {
+				stmts = append(stmts, d.dropCheckSQL(old.Name, old.Name, p.old.Name))
+			}
+		}
+		for _, f := range dropped {
+			if d.checkExpr(f) != "" {
+				stmts = append(stmts, d.dropCheckSQL(old.Name, old.Name, f.Name))
+			}
+		}
+	}
+	if renamed {
+		for _, f := range old.Fields {
+			if f.Unique {
+				stmts = append(stmts, d.dropIndexSQL(old.Name, f.Name))
+			}
+		}
+		if old.SoftDelete {
+			stmts = append(stmts, d.dropIndexSQL(old.Name, "deleted_at"))
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", d.quote(old.Name), d.quote(table.Name)))
+	}
+
+	if d.Name == DialectSQLite && rebuild {
+		return append(stmts, d.rebuildTableSQL(old, table, sources, fks)...)
+	}
+	if keysChanged {
+		stmts = append(stmts, fmt.Sprintf("-- 注意: 表 %s 的主键由 (%s) 变为 (%s), 请手工编写迁移",
+			table.Name, strings.Join(old.PrimaryKeys(), ", "), strings.Join(table.PrimaryKeys(), ", ")))
+	}
+
+	for _, p := range pairs {
+		of, f := p.old, p.new
+		if of.Unique && !renamed && (of.Name != f.Name || !f.Unique) {
+			stmts = append(stmts, d.dropIndexSQL(table.Name, of.Name))
+		}
+		if of.Name != f.Name {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;",
+				d.quote(table.Name), d.quote(of.Name), d.quote(f.Name)))
+		}
+		if columnModified(d, old, table, of, f) {
+			stmts = append(stmts, d.alterColumnSQL(table, of, f)...)
+		}
+		if f.Unique && (renamed || of.Name != f.Name || !of.Unique) {
+			stmts = append(stmts, d.createIndexSQL(table.Name, f.Name))
+		}
+	}
+	for _, f := range dropped {
+		if old.IsPrimaryKey(f.Name) {
+			stmts = append(stmts, fmt.Sprintf("-- 注意: 主键 %s 已删除, 请手工编写迁移", f.Name))
+			continue
+		}
+		if f.Unique && !renamed {
+			stmts = append(stmts, d.dropIndexSQL(table.Name, f.Name))
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", d.quote(table.Name), d.quote(f.Name)))
+	}
+	for _, f := range added {
+		stmts = append(stmts, d.addColumnSQL(table, f)...)
+		if f.Unique {
+			stmts = append(stmts, d.createIndexSQL(table.Name, f.Name))
+		}
+	}
+	switch {
+	case table.SoftDelete && !old.SoftDelete:
+		stmts = append(stmts,
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", d.quote(table.Name), d.quote("deleted_at"), d.timeType()),
+			d.createSoftDeleteIndexSQL(table.Name))
+	case !table.SoftDelete && old.SoftDelete:
+		if !renamed {
+			stmts = append(stmts, d.dropIndexSQL(table.Name, "deleted_at"))
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", d.quote(table.Name), d.quote("deleted_at")))
+	case table.SoftDelete && renamed:
+		stmts = append(stmts, d.createSoftDeleteIndexSQL(table.Name))
+	}
+	switch {
+	case table.Version && !old.Version:
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", d.quote(table.Name), d.quote("version"), d.versionType()))
+	case !table.Version && old.Version:
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", d.quote(table.Name), d.quote("version")))
+	}
+	if d.Name != DialectSQLite {
+		for _, p := range pairs {
+			if d.checkExpr(p.new) != "" && (d.checkExpr(p.old) == "" || d.recreateCheck(old, table, p)) {
+				stmts = append(stmts, d.addCheckSQL(table, p.new))
+			}
+		}
+	}
+	return stmts
+}
+
+// compositeKeyModified 判断复合主键的字段组成是否变化（按字段重命名对应）, 单一主键的变化由列定义对比处理
+func compositeKeyModified(old, table models.Table, fieldRenames map[string]string) bool {
+	oldKeys, keys := old.PrimaryKeys(), table.PrimaryKeys()
+	if len(oldKeys) <= 1 && len(keys) <= 1 {
+		return false
+	}
+	if len(oldKeys) != len(keys) {
+		return true
+	}
+	for i, k := range keys {
+		if r, ok := fieldRenames[k]; ok {
+			k = r
+		}
+		if k != oldKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// columnModified 判断列定义（类型、主键、非空、默认值）是否变化, 不含列名和枚举
+func columnModified(d dialect, oldTable, table models.Table, of, f models.Field) bool {
+	return d.columnDef(oldTable, of, false) != d.columnDef(table, f, false)
+}
+
+// enumModified 判断枚举取值是否变化
+func enumModified(of, f models.Field) bool {
+	return strings.Join(enumSQLValues(of), ",") != strings.Join(enumSQLValues(f), ",")
+}
+
+// recreateCheck 判断列的 CHECK 约束是否需要按新名称/新取值重建
+func (d dialect) recreateCheck(oldTable, table models.Table, p columnPair) bool {
+	return oldTable.Name != table.Name || p.old.Name != p.new.Name ||
+		enumModified(p.old, p.new) || columnModified(d, oldTable, table, p.old, p.new)
+}
+
+// canAddColumn 判断新增列能否直接 ADD COLUMN
+// SQLite 限制: 非主键、非空列需常量默认值、唯一列不能有默认值
+func (d dialect) canAddColumn(table models.Table, f models.Field) bool {
+	if table.IsPrimaryKey(f.Name) {
+		return false
+	}
+	if d.Name != DialectSQLite {
+		return true
+	}
+	if f.Required && f.Default == nil {
+		return false
+	}
+	if f.Default != nil && (f.Unique || f.IsTime()) {
+		return false
+	}
+	return true
+}
+
+// addColumnSQL 构建新增列语句, 非空且无默认值的列先以零值作为默认值填充已有数据
+func (d dialect) addColumnSQL(table models.Table, f models.Field) []string {
+	t, c := d.quote(table.Name), d.quote(f.Name)
+	if table.IsPrimaryKey(f.Name) {
+		return []string{fmt.Sprintf("-- 注意: 新增主键 %s, 请手工编写迁移", f.Name)}
+	}
+	if !f.Required || f.Default != nil {
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", t, c, d.columnType(table, f))}
+	}
+	def := strings.Replace(d.columnType(table, f), "NOT NULL", "NOT NULL DEFAULT "+zeroSQL(f), 1)
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", t, c, def),
+		fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", t, c),
+	}
+}
+
+// rebuildTableSQL 通过新建表、复制数据、替换旧表完成 SQLite 无法 ALTER 的变更
+// sources 为新字段名到旧字段名的映射, 新增或变为非空的列使用零值填充
+// 删除旧表时其他表对它的外键引用按表名保留, 改名后指向新表; 迁移执行器在迁移期间关闭外键检查, 提交前统一校验
+func (d dialect) rebuildTableSQL(old, table models.Table, sources map[string]string, fks []foreignKey) []string {
+	tmp := table.Name + "__new"
+	stmts := []string{
+		fmt.Sprintf("-- 重建表 %s: 字段定义或外键变化无法直接 ALTER\n%s", table.Name, d.createTableSQL(table, tmp, false, fks)),
+	}
+
+	oldRequired := make(map[string]bool)
+	for _, f := range old.Fields {
+		oldRequired[f.Name] = f.Required
+	}
+
+	var columns, values []string
+	for _, f := range table.Fields {
+		oldName, ok := sources[f.Name]
+		switch {
+		case ok && f.Required && !oldRequired[oldName]:
+			columns = append(columns, d.quote(f.Name))
+			values = append(values, fmt.Sprintf("COALESCE(%s, %s)", d.quote(oldName), zeroSQL(f)))
+		case ok:
+			columns = append(columns, d.quote(f.Name))
+			values = append(values, d.quote(oldName))
+		case f.Required && f.Default == nil && !f.AutoIncrement:
+			columns = append(columns, d.quote(f.Name))
+			values = append(values, zeroSQL(f))
+		}
+	}
+	autoColumns := []string{"created_at", "updated_at"}
+	if old.SoftDelete && table.SoftDelete {
+		autoColumns = append(autoColumns, "deleted_at")
+	}
+	if old.Version && table.Version {
+		autoColumns = append(autoColumns, "version")
+	}
+	for _, c := range autoColumns {
+		columns = append(columns, d.quote(c))
+		values = append(values, d.quote(c))
+	}
+
+	stmts = append(stmts,
+		fmt.Sprintf("INSERT INTO %s (%s)\nSELECT %s FROM %s;",
+			d.quote(tmp), strings.Join(columns, ", "), strings.Join(values, ", "), d.quote(table.Name)),
+		fmt.Sprintf("DROP TABLE %s;", d.quote(table.Name)),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", d.quote(tmp), d.quote(table.Name)),
+	)
+	return append(stmts, d.createIndexesSQL(table)...)
+}
+
+// ===================== 生成项目中的迁移执行器 =====================
+
+// buildMigrationsEmbed 构建嵌入迁移文件的包
+func (g *Generator) buildMigrationsEmbed() string {
+	var dirs []string
+	for _, d := range g.migrationDialects() {
+		dirs = append(dirs, d.Name)
+	}
+	return fmt.Sprintf(`package migrations
+
+import "embed"
+
+// FS 版本化迁移脚本, 按数据库类型分目录, 文件名格式: 0001_name.up.sql / 0001_name.down.sql
+//
+//go:embed %s
+var FS embed.FS
+`, strings.Join(dirs, " "))
+}
+
+// buildMigrationRunner 构建迁移执行器代码
+func (g *Generator) buildMigrationRunner() string {
+	return `package database
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"` + g.ModName + `/migrations"
+)
+
+// Migration 单个版本的迁移脚本
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// MigrateUp 按版本顺序执行所有未应用的迁移, 每个迁移在独立事务中执行
+// 注意: MySQL 的 DDL 会隐式提交, 迁移中途失败时需要手工修复
+func MigrateUp() error {
+	pending, _, err := splitMigrations()
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		err := migrate(func(tx *gorm.DB) error {
+			if err := execSQL(tx, m.Up); err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				m.Version, m.Name, time.Now()).Error
+		})
+		if err != nil {
+			return fmt.Errorf("执行迁移 %04d_%s 失败: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// MigrateDown 回滚最近的 steps 个迁移
+func MigrateDown(steps int) error {
+	_, applied, err := splitMigrations()
+	if err != nil {
+		return err
+	}
+	for i := len(applied) - 1; i >= 0 && steps > 0; i, steps = i-1, steps-1 {
+		m := applied[i]
+		err := migrate(func(tx *gorm.DB) error {
+			if err := execSQL(tx, m.Down); err != nil {
+				return err
+			}
+			return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("回滚迁移 %04d_%s 失败: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// migrate 在事务中执行单个迁移
+// SQLite 重建表时要先删除被其他表引用的旧表, 外键检查只能在事务外切换:
+// 在同一连接上关闭外键检查后执行迁移, 提交前用 foreign_key_check 校验所有外键, 结束后重新开启
+func migrate(fn func(tx *gorm.DB) error) error {
+	if DB.Dialector.Name() != "sqlite" {
+		return DB.Transaction(fn)
+	}
+	return DB.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("PRAGMA foreign_keys = OFF").Error; err != nil {
+			return err
+		}
+		defer conn.Exec("PRAGMA foreign_keys = ON")
+		return conn.Transaction(func(tx *gorm.DB) error {
+			if err := fn(tx); err != nil {
+				return err
+			}
+			return checkForeignKeys(tx)
+		})
+	})
+}
+
+// checkForeignKeys 检查 SQLite 中引用了不存在记录的外键
+func checkForeignKeys(tx *gorm.DB) error {
+	var violations []struct {
+		Table  string
+		Rowid  int64
+		Parent string
+	}
+	if err := tx.Raw("PRAGMA foreign_key_check").Scan(&violations).Error; err != nil {
+		return fmt.Errorf("检查外键失败: %w", err)
+	}
+	if len(violations) > 0 {
+		v := violations[0]
+		return fmt.Errorf("外键检查失败: 表 %s 的第 %d 行引用的 %s 记录不存在（共 %d 处）", v.Table, v.Rowid, v.Parent, len(violations))
+	}
+	return nil
+}
+
+// GetMigrationStatus 查询所有迁移的应用状态
+func GetMigrationStatus() ([]MigrationStatus, error) {
+	all, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	records, err := appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MigrationStatus, len(all))
+	for i, m := range all {
+		result[i] = MigrationStatus{Version: m.Version, Name: m.Name}
+		if at, ok := records[m.Version]; ok {
+			result[i].Applied = true
+			result[i].AppliedAt = &at
+		}
+	}
+	return result, nil
+}
+
+// splitMigrations 返回未应用和已应用的迁移, 均按版本升序
+func splitMigrations() (pending, applied []Migration, err error) {
+	all, err := loadMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := appliedMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, m := range all {
+		if _, ok := records[m.Version]; ok {
+			applied = append(applied, m)
+		} else {
+			pending = append(pending, m)
+		}
+	}
+	return pending, applied, nil
+}
+
+// appliedMigrations 读取 schema_migrations 表, 表不存在时自动创建
+func appliedMigrations() (map[int]time.Time, error) {
+	err := DB.Exec(` + "`" + `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL
+)` + "`" + `).Error
+	if err != nil {
+		return nil, fmt.Errorf("创建 schema_migrations 表失败: %w", err)
+	}
+
+	var rows []struct {
+		Version   int
+		AppliedAt time.Time
+	}
+	if err := DB.Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("查询迁移记录失败: %w", err)
+	}
+	records := make(map[int]time.Time, len(rows))
+	for _, r := range rows {
+		records[r.Version] = r.AppliedAt
+	}
+	return records, nil
+}
+
+// loadMigrations 读取当前数据库类型对应目录下的迁移文件
+func loadMigrations() ([]Migration, error) {
+	dir, err := fs.Sub(migrations.FS, DB.Dialector.Name())
+	if err != nil {
+		return nil, fmt.Errorf("读取迁移文件失败: %w", err)
+	}
+	entries, err := fs.ReadDir(dir, ".")
+	if err != nil {
+		return nil, fmt.Errorf("没有 %s 的迁移文件: %w", DB.Dialector.Name(), err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		name := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		prefix, rest, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("迁移文件名无效: %s", name)
+		}
+		content, err := fs.ReadFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: strings.TrimSuffix(rest, "."+direction+".sql")}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// execSQL 逐条执行迁移脚本, 语句以行尾分号结束, 忽略 -- 注释行
+func execSQL(tx *gorm.DB, script string) error {
+	var stmt strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		stmt.WriteString(line)
+		stmt.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			if err := tx.Exec(stmt.String()).Error; err != nil {
+				return err
+			}
+			stmt.Reset()
+		}
+	}
+	if strings.TrimSpace(stmt.String()) != "" {
+		return tx.Exec(stmt.String()).Error
+	}
+	return nil
+}
+`
+}
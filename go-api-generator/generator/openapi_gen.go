@@ -0,0 +1,777 @@
+package generator
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go-api-generator/models"
+	"path"
+	"strings"
+)
+
+// swaggerUIAssets Swagger UI 静态资源（swagger-ui-dist 5.18.2）, 原样复制到生成项目的 handlers/swagger 目录
+//
+//go:embed assets/swagger-ui/swagger-ui.css assets/swagger-ui/swagger-ui-bundle.js assets/swagger-ui/LICENSE
+var swaggerUIAssets embed.FS
+
+// swaggerAssetDir 生成项目中 Swagger UI 静态资源的目录
+const swaggerAssetDir = "handlers/swagger"
+
+// generateOpenAPI 生成 OpenAPI 3 文档、Swagger UI 处理器和静态资源
+func (g *Generator) generateOpenAPI() error {
+	spec, err := json.MarshalIndent(g.buildOpenAPISpec(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化 OpenAPI 文档失败: %w", err)
+	}
+	if err := g.writeFile("openapi.json", string(spec)+"\n"); err != nil {
+		return err
+	}
+	entries, err := swaggerUIAssets.ReadDir("assets/swagger-ui")
+	if err != nil {
+		return fmt.Errorf("读取 Swagger UI 静态资源失败: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := swaggerUIAssets.ReadFile(path.Join("assets/swagger-ui", entry.Name()))
+		if err != nil {
+			return fmt.Errorf("读取 Swagger UI 静态资源失败: %w", err)
+		}
+		if err := g.writeFile(path.Join(swaggerAssetDir, entry.Name()), string(data)); err != nil {
+			return err
+		}
+	}
+	return g.writeFile("handlers/swagger.go", g.buildSwaggerHandler())
+}
+
+// buildOpenAPISpec 构建 OpenAPI 3.0 文档结构
+func (g *Generator) buildOpenAPISpec() map[string]any {
+	schemas := map[string]any{
+		"Response": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "integer", "description": "0 表示成功, -1 表示失败"},
+				"message": map[string]any{"type": "string"},
+				"data":    map[string]any{},
+			},
+			"required": []string{"code", "message"},
+		},
+		"PageData": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"list":        map[string]any{"type": "array", "items": map[string]any{}},
+				"total":       map[string]any{"type": "integer", "format": "int64", "description": "总数, 请求 skip_total 时省略"},
+				"page":        map[string]any{"type": "integer", "description": "页码, 游标分页时省略"},
+				"page_size":   map[string]any{"type": "integer"},
+				"next_cursor": map[string]any{"type": "string", "description": "游标分页的下一页游标, 没有下一页时省略"},
+			},
+		},
+		"FailedItem": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"index":   map[string]any{"type": "integer", "description": "出错记录的下标, 从 0 开始"},
+				"row":     map[string]any{"type": "integer", "description": "导入时出错记录在文件中的行号, 表头为第 1 行"},
+				"message": map[string]any{"type": "string"},
+			},
+			"required": []string{"index", "message"},
+		},
+		"IDsRequest": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"ids": map[string]any{"type": "array", "items": map[string]any{"type": "integer", "format": "int64"}},
+			},
+			"required": []string{"ids"},
+		},
+	}
+	paths := map[string]any{}
+
+	for _, model := range g.Models {
+		schemas[model.Name] = g.modelSchema(model)
+		if isCompositeKey(model) {
+			schemas[model.Name+"Key"] = keyObjectSchema(model)
+		}
+		schemas["Create"+model.Name+"Request"] = createRequestSchema(model)
+		schemas["Update"+model.Name+"Request"] = updateRequestSchema(model)
+		schemas["Replace"+model.Name+"Request"] = replaceRequestSchema(model)
+		g.addModelPaths(paths, schemas, model)
+	}
+
+	components := map[string]any{"schemas": schemas}
+	if g.authEnabled() {
+		g.addAuthPaths(paths, schemas)
+		components["securitySchemes"] = map[string]any{
+			"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+		}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       g.ModName,
+			"description": g.Config.Description,
+			"version":     g.Config.Version,
+		},
+		"servers":    []any{map[string]any{"url": "http://localhost:8080"}},
+		"paths":      paths,
+		"components": components,
+	}
+}
+
+// modelSchema 构建模型的 schema, 包含公共字段和关联字段
+func (g *Generator) modelSchema(model GoModelWrapper) map[string]any {
+	properties := map[string]any{}
+	for _, field := range model.Fields {
+		properties[field.JsonName] = fieldSchema(field)
+	}
+	for _, assoc := range model.Associations {
+		ref := map[string]any{"$ref": "#/components/schemas/" + assoc.Model}
+		if assoc.Kind == "has-many" || assoc.Kind == "many2many" {
+			properties[assoc.JsonName] = map[string]any{"type": "array", "items": ref}
+		} else {
+			properties[assoc.JsonName] = ref
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if model.Description != "" {
+		schema["description"] = model.Description
+	}
+	return schema
+}
+
+// createRequestSchema 构建创建请求的 schema
+func createRequestSchema(model GoModelWrapper) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, field := range model.Fields {
+		if isAutoField(model, field) {
+			continue
+		}
+		if strings.Contains(field.GormTag, "autoIncrement") || isUUIDKey(model, field) {
+			continue
+		}
+		properties[field.JsonName] = fieldSchema(field)
+		if strings.HasPrefix(field.ValidateTag, "required") {
+			required = append(required, field.JsonName)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// updateRequestSchema 构建部分更新请求的 schema, 所有字段可选, 非必填字段可以为 null
+func updateRequestSchema(model GoModelWrapper) map[string]any {
+	properties := map[string]any{}
+	for _, field := range updateFields(model) {
+		schema := fieldSchema(field)
+		if !notNullField(field) {
+			schema["nullable"] = true
+		}
+		properties[field.JsonName] = schema
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// replaceRequestSchema 构建整体替换请求的 schema, 必填字段与创建请求一致
+func replaceRequestSchema(model GoModelWrapper) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, field := range updateFields(model) {
+		properties[field.JsonName] = fieldSchema(field)
+		if notNullField(field) {
+			required = append(required, field.JsonName)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// fieldSchema 将字段映射为 OpenAPI 类型
+func fieldSchema(field models.GoField) map[string]any {
+	schema := map[string]any{}
+	raw := field.Raw
+
+	switch strings.TrimPrefix(field.GoType, "*") {
+	case "int64":
+		schema["type"] = "integer"
+		schema["format"] = "int64"
+		// bigint 在 JSON 中以字符串传输
+		if raw.Type == "bigint" {
+			schema["type"] = "string"
+		}
+	case "float64":
+		schema["type"] = "number"
+		schema["format"] = "double"
+	case "bool":
+		schema["type"] = "boolean"
+	case "time.Time":
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+	case "gorm.DeletedAt":
+		schema["type"] = "string"
+		schema["format"] = "date-time"
+		schema["nullable"] = true
+	case "datatypes.JSON":
+		// 任意 JSON 值, 不限制类型
+	case "[]byte":
+		schema["type"] = "string"
+		schema["format"] = "byte"
+	default:
+		schema["type"] = "string"
+	}
+
+	switch {
+	case raw.Type == "time":
+		schema["pattern"] = `^([01]\d|2[0-3]):[0-5]\d:[0-5]\d$`
+		schema["example"] = "09:30:00"
+	case raw.IsDecimalText():
+		schema["pattern"] = decimalPattern(raw)
+		schema["example"] = decimalExample(raw)
+	}
+
+	switch raw.Format {
+	case "email", "uuid":
+		schema["format"] = raw.Format
+	case "url":
+		schema["format"] = "uri"
+	}
+	if (raw.Type == "string" || raw.Type == "enum") && raw.Length > 0 {
+		schema["maxLength"] = raw.Length
+	}
+	if hasEnum(raw) {
+		schema["enum"] = raw.Enum
+	}
+	if raw.Default != nil {
+		schema["default"] = raw.Default
+	}
+	if strings.HasPrefix(field.GoType, "*") {
+		schema["nullable"] = true
+	}
+	if field.Comment != "" {
+		schema["description"] = field.Comment
+	}
+	if raw.IsCents() {
+		schema["description"] = fmt.Sprintf("%s（按 10^%d 放大后的整数）", field.Comment, raw.Scale)
+	}
+	return schema
+}
+
+// addModelPaths 添加单个模型的 CRUD 及嵌套路由
+func (g *Generator) addModelPaths(paths, schemas map[string]any, model GoModelWrapper) {
+	base := fmt.Sprintf("/api/v1/%ss", strings.ToLower(model.TableName))
+	tag := model.Name
+	ref := "#/components/schemas/" + model.Name
+
+	listParams := g.listParams(model)
+	var getParams []any
+	if len(model.Associations) > 0 {
+		getParams = append(getParams, queryParam("include", "string", "预加载的关联, 逗号分隔"))
+	}
+
+	t := model.TableName
+	keyParams := keyParams(model)
+	itemPath := base
+	for _, f := range keyFields(model) {
+		if isCompositeKey(model) {
+			itemPath += "/{" + f.JsonName + "}"
+		} else {
+			itemPath += "/{id}"
+		}
+	}
+	paths[base] = map[string]any{
+		"post": g.secure(t, authCreate, operation(tag, "创建"+model.Description, nil,
+			jsonBody("#/components/schemas/Create"+model.Name+"Request"), dataResponse(ref))),
+		"get": g.secure(t, authRead, operation(tag, "获取"+model.Description+"列表", listParams, nil, pageResponse(ref))),
+	}
+	paths[itemPath] = map[string]any{
+		"get": g.secure(t, authRead, operation(tag, "根据ID获取"+model.Description, append(keyParams, getParams...), nil, dataResponse(ref))),
+		"put": g.secure(t, authUpdate, operation(tag, "整体替换"+model.Description, keyParams,
+			jsonBody("#/components/schemas/Replace"+model.Name+"Request"), messageResponse())),
+		"patch": g.secure(t, authUpdate, operation(tag, "部分更新"+model.Description, keyParams,
+			mergePatchBody("#/components/schemas/Update"+model.Name+"Request"), messageResponse())),
+		"delete": g.secure(t, authDelete, operation(tag, "删除"+model.Description, keyParams, nil, messageResponse())),
+	}
+	if model.Version {
+		addVersionHeaders(paths[itemPath].(map[string]any))
+	}
+	if model.SoftDelete {
+		paths[itemPath+"/restore"] = map[string]any{
+			"post": g.secure(t, authDelete, operation(tag, "从回收站恢复"+model.Description, keyParams, nil, messageResponse())),
+		}
+		paths[itemPath+"/purge"] = map[string]any{
+			"delete": g.secure(t, authDelete, operation(tag, "彻底删除"+model.Description, keyParams, nil, messageResponse())),
+		}
+	}
+	var keyItems map[string]any
+	if isCompositeKey(model) {
+		keyItems = map[string]any{"$ref": "#/components/schemas/" + model.Name + "Key"}
+	} else {
+		keyItems = keySchema(keyFields(model)[0])
+	}
+	list := map[string]any{"type": "array", "items": map[string]any{"$ref": ref}}
+	createRef := map[string]any{"$ref": "#/components/schemas/Create" + model.Name + "Request"}
+	updateItem := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":   keyItems,
+			"data": map[string]any{"$ref": "#/components/schemas/Update" + model.Name + "Request"},
+		},
+		"required": []string{"id", "data"},
+	}
+	paths[base+"/batch"] = map[string]any{
+		"post":  g.secure(t, authCreate, batchOperation(tag, "批量创建"+model.Description, nil, itemsBody(createRef), dataResponse(list))),
+		"patch": g.secure(t, authUpdate, batchOperation(tag, "批量部分更新"+model.Description, nil, itemsBody(updateItem), messageResponse())),
+	}
+	if unique := uniqueFields(model); len(unique) > 0 {
+		var columns []string
+		for _, f := range unique {
+			columns = append(columns, f.JsonName)
+		}
+		on := queryParam("on", "string", "冲突时匹配的唯一列")
+		on["schema"] = map[string]any{"type": "string", "enum": columns, "default": columns[0]}
+		upsert := batchOperation(tag, "按唯一列批量插入或更新"+model.Description, []any{on}, itemsBody(createRef), dataResponse(list))
+		paths[base+"/upsert"] = map[string]any{
+			"put": g.secure(t, authCreate, g.secure(t, authUpdate, upsert)),
+		}
+	}
+	paths[base+"/export"] = map[string]any{
+		"get": g.secure(t, authRead, operation(tag, "按列表的过滤条件导出"+model.Description, g.exportParams(model), nil, fileResponse())),
+	}
+	importOp := batchOperation(tag, "从 CSV 或 Excel 文件导入"+model.Description, nil, uploadBody(), dataResponse(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"count": map[string]any{"type": "integer", "description": "导入的记录数"}},
+	}))
+	paths[base+"/import"] = map[string]any{
+		"post": g.secure(t, authCreate, importOp),
+	}
+	paths[base+"/batch-delete"] = map[string]any{
+		"post": g.secure(t, authDelete, operation(tag, "批量删除"+model.Description, nil,
+			jsonBody(idsRequestRef(schemas, model.Name, keyItems)), messageResponse())),
+	}
+
+	for _, assoc := range model.Associations {
+		path := fmt.Sprintf("%s/{id}/%s", base, assoc.JsonName)
+		target := "#/components/schemas/" + assoc.Model
+		// has one / has many 的 id 为外键值, many2many 的 id 为本模型主键
+		idParams := keyParams
+		if fk := findField(*g.findModel(assoc.TableName), assoc.ForeignColumn); fk != nil && assoc.Kind != "many2many" {
+			idParams = []any{idParam("id", keySchema(*fk))}
+		}
+		switch assoc.Kind {
+		case "has-many":
+			paths[path] = map[string]any{
+				"get": g.secure(assoc.TableName, authRead, operation(assoc.Model, "根据"+model.Description+"ID获取"+assoc.Description+"列表",
+					append(idParams, g.listParams(*g.findModel(assoc.TableName))...), nil, pageResponse(target))),
+			}
+		case "has-one":
+			paths[path] = map[string]any{
+				"get": g.secure(assoc.TableName, authRead, operation(assoc.Model, "根据"+model.Description+"ID获取"+assoc.Description,
+					idParams, nil, dataResponse(target))),
+			}
+		case "many2many":
+			body := jsonBody(idsRequestRef(schemas, assoc.Model, keySchema(*findField(*g.findModel(assoc.TableName), assoc.ReferenceColumn))))
+			paths[path] = map[string]any{
+				"get": g.secure(assoc.TableName, authRead, operation(tag, "获取"+model.Description+"关联的"+assoc.Description,
+					idParams, nil, dataResponse(map[string]any{"type": "array", "items": map[string]any{"$ref": target}}))),
+				"post": g.secure(t, authUpdate, operation(tag, "添加"+model.Description+"关联的"+assoc.Description,
+					idParams, body, messageResponse())),
+				"delete": g.secure(t, authUpdate, operation(tag, "移除"+model.Description+"关联的"+assoc.Description,
+					idParams, body, messageResponse())),
+			}
+		}
+	}
+}
+
+// listParams 列表接口的查询参数
+func (g *Generator) listParams(model GoModelWrapper) []any {
+	params := []any{
+		queryParam("page", "integer", "页码, 默认 1"),
+		queryParam("page_size", "integer", "每页条数, 默认 20, 最大 100"),
+		queryParam("cursor", "string", "游标分页: 首页传空值, 之后传上一页返回的 next_cursor; 传入时忽略 page"),
+		queryParam("skip_total", "boolean", "不统计总数"),
+		g.orderByParam(model),
+		orderParam(),
+		queryParam("keyword", "string", "关键字搜索"),
+	}
+	if len(model.Associations) > 0 {
+		params = append(params, queryParam("include", "string", "预加载的关联, 逗号分隔"))
+	}
+	if model.SoftDelete {
+		param := queryParam("trashed", "string", "回收站: only 只查已删除, with 包含已删除, 默认排除已删除")
+		param["schema"] = map[string]any{"type": "string", "enum": []string{"only", "with"}}
+		params = append(params, param)
+	}
+	for _, f := range g.listFilters(model) {
+		param := queryParam(f.Param, "", f.Description)
+		param["schema"] = filterSchemaType(f)
+		params = append(params, param)
+	}
+	return params
+}
+
+// exportParams 导出接口的查询参数: 列表的过滤和排序参数（不含分页、计数和预加载）及导出格式
+func (g *Generator) exportParams(model GoModelWrapper) []any {
+	format := queryParam("format", "string", "导出格式, 默认 csv")
+	format["schema"] = map[string]any{"type": "string", "enum": []string{"csv", "xlsx"}, "default": "csv"}
+	params := []any{format}
+	for _, param := range g.listParams(model) {
+		switch param.(map[string]any)["name"] {
+		case "page", "page_size", "cursor", "skip_total", "include":
+			continue
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// orderByParam 排序参数, 列出允许排序的列
+func (g *Generator) orderByParam(model GoModelWrapper) map[string]any {
+	columns := make([]string, len(model.Fields))
+	for i, f := range model.Fields {
+		columns[i] = f.JsonName
+	}
+	return queryParam("order_by", "string",
+		"排序列, 逗号分隔, 前缀 - 表示降序, 如 -created_at。可选: "+strings.Join(columns, ", "))
+}
+
+// orderParam 排序方向参数
+func orderParam() map[string]any {
+	param := queryParam("order", "string", "无前缀排序列的方向, 默认 asc")
+	param["schema"] = map[string]any{"type": "string", "enum": []string{"asc", "desc"}}
+	return param
+}
+
+// operation 构建单个接口定义, 统一附加错误响应
+func operation(tag, summary string, params []any, body map[string]any, success map[string]any) map[string]any {
+	op := map[string]any{
+		"tags":    []string{tag},
+		"summary": summary,
+		"responses": map[string]any{
+			"200": success,
+			"400": errorResponse("参数错误"),
+			"404": errorResponse("资源不存在"),
+			"500": errorResponse("服务器内部错误"),
+		},
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if body != nil {
+		op["requestBody"] = body
+	}
+	return op
+}
+
+// addVersionHeaders 为启用乐观锁的模型补充条件请求: 查询返回 ETag 并支持 If-None-Match（304）,
+// 更新和删除支持 If-Match, 版本不一致时返回 412
+func addVersionHeaders(item map[string]any) {
+	header := func(name, description string) map[string]any {
+		return map[string]any{
+			"name":        name,
+			"in":          "header",
+			"description": description,
+			"schema":      map[string]any{"type": "string"},
+		}
+	}
+
+	get := item["get"].(map[string]any)
+	get["parameters"] = append(get["parameters"].([]any), header("If-None-Match", "上次获取的 ETag, 记录未修改时返回 304"))
+	responses := get["responses"].(map[string]any)
+	responses["200"].(map[string]any)["headers"] = map[string]any{
+		"ETag": map[string]any{"description": "记录的版本号", "schema": map[string]any{"type": "string"}},
+	}
+	responses["304"] = map[string]any{"description": "记录未修改"}
+
+	for _, method := range []string{"put", "patch", "delete"} {
+		op := item[method].(map[string]any)
+		// 各方法共用主键参数切片, 复制后再追加
+		op["parameters"] = append(append([]any{}, op["parameters"].([]any)...), header("If-Match", "获取记录时的 ETag, 记录已被修改时返回 412"))
+		op["responses"].(map[string]any)["412"] = errorResponse("记录已被修改")
+	}
+}
+
+// batchOperation 批量写入接口, 400 响应的 data 列出出错的记录
+func batchOperation(tag, summary string, params []any, body, success map[string]any) map[string]any {
+	op := operation(tag, summary, params, body, success)
+	responses := op["responses"].(map[string]any)
+	delete(responses, "404")
+	responses["400"] = map[string]any{
+		"description": "参数错误, 或某条记录写入失败（整批已回滚）",
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{
+					"allOf": []any{
+						map[string]any{"$ref": "#/components/schemas/Response"},
+						map[string]any{"type": "object", "properties": map[string]any{
+							"data": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/components/schemas/FailedItem"}},
+						}},
+					},
+				},
+			},
+		},
+	}
+	return op
+}
+
+// itemsBody 批量请求体, 单次最多 1000 条
+func itemsBody(items map[string]any) map[string]any {
+	return map[string]any{
+		"required": true,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"items": map[string]any{"type": "array", "items": items, "minItems": 1, "maxItems": 1000},
+				},
+				"required": []string{"items"},
+			}},
+		},
+	}
+}
+
+// uploadBody 导入文件的 multipart 请求体, 格式按文件扩展名确定
+func uploadBody() map[string]any {
+	return map[string]any{
+		"required": true,
+		"content": map[string]any{
+			"multipart/form-data": map[string]any{"schema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"file": map[string]any{"type": "string", "format": "binary", "description": "CSV 或 Excel 文件, 表头为字段名或字段注释"},
+				},
+				"required": []string{"file"},
+			}},
+		},
+	}
+}
+
+// fileResponse 导出文件的响应
+func fileResponse() map[string]any {
+	file := map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}}
+	return map[string]any{
+		"description": "导出的文件",
+		"content": map[string]any{
+			"text/csv": file,
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": file,
+		},
+	}
+}
+
+// errorResponse 错误响应, 结构为统一响应
+func errorResponse(description string) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Response"}},
+		},
+	}
+}
+
+// idParam 路径中的 ID 参数
+func idParam(name string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"name":     name,
+		"in":       "path",
+		"required": true,
+		"schema":   schema,
+	}
+}
+
+// keyParams 路径中的主键参数, 单一主键为 id, 复合主键每个字段一个参数
+func keyParams(model GoModelWrapper) []any {
+	fields := keyFields(model)
+	if !isCompositeKey(model) {
+		return []any{idParam("id", keySchema(fields[0]))}
+	}
+	params := make([]any, len(fields))
+	for i, f := range fields {
+		params[i] = idParam(f.JsonName, keySchema(f))
+	}
+	return params
+}
+
+// keySchema 主键或外键的类型: 整数或字符串（uuid 格式时带 format）
+func keySchema(field models.GoField) map[string]any {
+	if strings.TrimPrefix(field.GoType, "*") == "int64" {
+		return map[string]any{"type": "integer", "format": "int64"}
+	}
+	if field.Raw.Format == "uuid" {
+		return map[string]any{"type": "string", "format": "uuid"}
+	}
+	return map[string]any{"type": "string"}
+}
+
+// keyObjectSchema 复合主键对象, 用于批量删除的 ids
+func keyObjectSchema(model GoModelWrapper) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for _, f := range keyFields(model) {
+		properties[f.JsonName] = keySchema(f)
+		required = append(required, f.JsonName)
+	}
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
+
+// idsRequestRef 批量操作请求体的 schema 引用, 整数 ID 共用 IDsRequest, 其他类型按模型生成 XxxIDsRequest
+func idsRequestRef(schemas map[string]any, model string, items map[string]any) string {
+	if items["type"] == "integer" {
+		return "#/components/schemas/IDsRequest"
+	}
+	name := model + "IDsRequest"
+	schemas[name] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"ids": map[string]any{"type": "array", "items": items},
+		},
+		"required": []string{"ids"},
+	}
+	return "#/components/schemas/" + name
+}
+
+// queryParam 查询参数
+func queryParam(name, typ, description string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"in":          "query",
+		"description": description,
+		"schema":      map[string]any{"type": typ},
+	}
+}
+
+// jsonBody JSON 请求体
+func jsonBody(ref string) map[string]any {
+	return map[string]any{
+		"required": true,
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{"$ref": ref}},
+		},
+	}
+}
+
+// mergePatchBody JSON Merge Patch 请求体, 同时接受 application/json
+func mergePatchBody(ref string) map[string]any {
+	body := jsonBody(ref)
+	content := body["content"].(map[string]any)
+	content["application/merge-patch+json"] = content["application/json"]
+	return body
+}
+
+// envelope 将 data 的 schema 包装进统一响应结构
+func envelope(data any) map[string]any {
+	return map[string]any{
+		"description": "成功",
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{
+					"allOf": []any{
+						map[string]any{"$ref": "#/components/schemas/Response"},
+						map[string]any{"type": "object", "properties": map[string]any{"data": data}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataResponse 单个对象响应, 参数可以是 $ref 路径或完整 schema
+func dataResponse(data any) map[string]any {
+	if ref, ok := data.(string); ok {
+		data = map[string]any{"$ref": ref}
+	}
+	return envelope(data)
+}
+
+// pageResponse 分页响应
+func pageResponse(ref string) map[string]any {
+	return envelope(map[string]any{
+		"allOf": []any{
+			map[string]any{"$ref": "#/components/schemas/PageData"},
+			map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"list": map[string]any{"type": "array", "items": map[string]any{"$ref": ref}},
+				},
+			},
+		},
+	})
+}
+
+// messageResponse 仅包含消息的响应
+func messageResponse() map[string]any {
+	return map[string]any{
+		"description": "成功",
+		"content": map[string]any{
+			"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Response"}},
+		},
+	}
+}
+
+// buildSwaggerHandler 构建 OpenAPI 文档和 Swagger UI 的处理器代码
+func (g *Generator) buildSwaggerHandler() string {
+	return `package handlers
+
+import (
+	"embed"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerAssets Swagger UI 静态资源（swagger-ui-dist 5.18.2, Apache License 2.0, 见 swagger/LICENSE）,
+// 嵌入二进制文件, 离线环境也能打开文档页面
+//
+//go:embed swagger/swagger-ui.css swagger/swagger-ui-bundle.js
+var swaggerAssets embed.FS
+
+// openAPISpec OpenAPI 文档内容, 由 main 包嵌入 openapi.json 后设置
+var openAPISpec []byte
+
+// SetOpenAPISpec 设置 OpenAPI 文档内容
+func SetOpenAPISpec(spec []byte) {
+	openAPISpec = spec
+}
+
+// OpenAPISpec 返回 OpenAPI 文档
+func OpenAPISpec(c *gin.Context) {
+	if len(openAPISpec) == 0 {
+		NotFound(c, "OpenAPI 文档未加载")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPISpec)
+}
+
+// SwaggerUI 返回 Swagger UI 页面
+func SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerHTML))
+}
+
+// SwaggerAsset 返回 Swagger UI 的静态资源 /swagger/swagger-ui.css 和 /swagger/swagger-ui-bundle.js
+func SwaggerAsset(c *gin.Context) {
+	c.FileFromFS(path.Join("swagger", path.Base(c.Request.URL.Path)), http.FS(swaggerAssets))
+}
+
+// swaggerHTML Swagger UI 页面, 静态资源由 SwaggerAsset 提供
+const swaggerHTML = ` + "`" + `<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+  <meta charset="utf-8">
+  <title>API 文档</title>
+  <link rel="stylesheet" href="/swagger/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/swagger/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      SwaggerUIBundle({ url: "/swagger/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+` + "`" + `
+`
+}
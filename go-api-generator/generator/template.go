@@ -0,0 +1,444 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go-api-generator/models"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplates 内置模板, 可通过 -templates 目录中的同名文件覆盖
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// templateData 模板数据
+type templateData struct {
+	Mod     string           // 生成项目的 Go module 名称
+	Dialect dialect          // 目标数据库方言
+	Models  []models.GoModel // 所有模型
+	Model   models.GoModel   // 当前模型, 按模型生成的文件中使用
+}
+
+// enumGroup 枚举字段及其常量, 用于模型模板
+type enumGroup struct {
+	GoName  string
+	Comment string
+	Consts  []enumConst
+}
+
+// enumConst 枚举常量
+type enumConst struct {
+	Name    string
+	Type    string
+	Literal string
+}
+
+// joinTableSetup 多对多关联的中间表注册信息
+type joinTableSetup struct {
+	Model models.GoModel
+	Assoc models.GoAssociation
+}
+
+// TemplateNames 返回所有内置模板名, 即 -templates 目录中可覆盖的文件名
+func TemplateNames() []string {
+	entries, _ := fs.ReadDir(defaultTemplates, "templates")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadTemplates 加载内置模板, 再用 TemplateDir 中的同名文件覆盖
+func (g *Generator) loadTemplates() error {
+	tmpl, err := template.New("").Funcs(g.templateFuncs()).ParseFS(defaultTemplates, "templates/*.tmpl")
+	if err != nil {
+		return fmt.Errorf("解析内置模板失败: %w", err)
+	}
+
+	if g.TemplateDir != "" {
+		known := make(map[string]bool)
+		for _, name := range TemplateNames() {
+			known[name] = true
+		}
+		paths, err := filepath.Glob(filepath.Join(g.TemplateDir, "*.tmpl"))
+		if err != nil {
+			return err
+		}
+		if len(paths) == 0 {
+			return fmt.Errorf("模板目录 %s 中没有 .tmpl 文件", g.TemplateDir)
+		}
+		for _, path := range paths {
+			name := filepath.Base(path)
+			if !known[name] {
+				return fmt.Errorf("未知模板 %s（可覆盖: %s）", name, strings.Join(TemplateNames(), ", "))
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("读取模板失败: %w", err)
+			}
+			if _, err := tmpl.New(name).Parse(string(data)); err != nil {
+				return fmt.Errorf("解析模板 %s 失败: %w", path, err)
+			}
+			fmt.Printf("   🎨 使用自定义模板: %s\n", name)
+		}
+	}
+
+	g.templates = tmpl
+	return nil
+}
+
+// render 执行模板, 返回生成的代码
+func (g *Generator) render(name string, model *models.GoModel) (string, error) {
+	data := templateData{
+		Mod:     g.ModName,
+		Dialect: g.dialect(),
+		Models:  g.Models,
+	}
+	if model != nil {
+		data.Model = *model
+	}
+	var buf bytes.Buffer
+	if err := g.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("执行模板失败: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// renderFile 执行模板并写入生成的文件
+func (g *Generator) renderFile(relPath, name string, model *models.GoModel) error {
+	code, err := g.render(name, model)
+	if err != nil {
+		return err
+	}
+	return g.writeFile(relPath, code)
+}
+
+// templateFuncs 模板中可用的函数
+func (g *Generator) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":         strings.ToLower,
+		"pascal":        ToPascalCase,
+		"camel":         ToCamelCase,
+		"quote":         strconv.Quote,
+		"baseType":      func(t string) string { return strings.TrimPrefix(t, "*") },
+		"pkGoName":      pkGoName,
+		"pkColumn":      pkColumn,
+		"keyFields":     keyFields,
+		"keyType":       keyType,
+		"keyWhere":      keyWhere,
+		"keyPath":       keyPath,
+		"composite":     isCompositeKey,
+		"uuidKey":       uuidKey,
+		"modelImports":  modelImports,
+		"hasJSON":       func(model models.GoModel) bool { return hasField(model, isJSONField) },
+		"usesJSON":      func() bool { return g.usesField(isJSONField) },
+		"usesPointer":   func() bool { return g.usesField(needsPointer) },
+		"usesVersion":   g.usesVersion,
+		"pathParser":    pathParser,
+		"fieldParser":   fieldParser,
+		"fieldTags":     fieldTags,
+		"assocType":     assocGoType,
+		"assocTag":      assocGormTag,
+		"enums":         enumGroups,
+		"createFields":  createFields,
+		"updateFields":  updateFields,
+		"updateType":    updateGoType,
+		"uniqueFields":  uniqueFields,
+		"upsertColumns": upsertColumns,
+		"importHeaders": importHeaders,
+		"cursorFields":  cursorFields,
+		"updateTags":    updateTags,
+		"notNull":       notNullField,
+		"resetValue":    resetValue,
+		"hasDefault":    hasDefault,
+		"keywordSearch": keywordSearch,
+		"hasMany2Many":  hasMany2Many,
+		"filters":       g.listFilters,
+		"filterOp":      filterSQLOp,
+		"inverse":       g.inverseAssociations,
+		"joinTables":    g.joinTables,
+		"authEnabled":   g.authEnabled,
+		"guard":         g.authGuard,
+		"isSQLite":      func(d dialect) bool { return d.Name == DialectSQLite },
+	}
+}
+
+// fieldTags 构建字段标签
+func fieldTags(field models.GoField) string {
+	var parts []string
+
+	parts = append(parts, fmt.Sprintf("json:\"%s\"", field.JsonTag))
+
+	if field.GormTag != "" {
+		parts = append(parts, fmt.Sprintf("gorm:\"%s\"", field.GormTag))
+	}
+
+	if field.ValidateTag != "" {
+		parts = append(parts, fmt.Sprintf("binding:\"%s\"", field.ValidateTag))
+	}
+
+	return fmt.Sprintf("`%s`", strings.Join(parts, " "))
+}
+
+// isAutoTimeField 判断是否为自动维护的时间字段（含软删除的 DeletedAt）
+func isAutoTimeField(field models.GoField) bool {
+	return field.GoName == "CreatedAt" || field.GoName == "UpdatedAt" || field.GoName == "DeletedAt"
+}
+
+// isAutoField 判断是否为生成器自动维护的字段: 时间字段和乐观锁的版本号
+func isAutoField(model models.GoModel, field models.GoField) bool {
+	return isAutoTimeField(field) || model.Version && field.GoName == "Version"
+}
+
+// usesVersion 判断是否有表启用乐观锁
+func (g *Generator) usesVersion() bool {
+	for _, model := range g.Models {
+		if model.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// createFields 创建 DTO 中的字段, 不包含自动字段、自增主键和自动生成的 UUID 主键
+func createFields(model models.GoModel) []models.GoField {
+	var fields []models.GoField
+	for _, field := range model.Fields {
+		if isAutoField(model, field) || strings.Contains(field.GormTag, "autoIncrement") || isUUIDKey(model, field) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// updateFields 更新 DTO 中的字段, 不包含自动字段和主键
+func updateFields(model models.GoModel) []models.GoField {
+	var fields []models.GoField
+	for _, field := range model.Fields {
+		if isAutoField(model, field) || strings.Contains(field.GormTag, "primaryKey") {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// uniqueFields 可作为 upsert 冲突键的唯一字段, 不包含主键
+func uniqueFields(model models.GoModel) []models.GoField {
+	var fields []models.GoField
+	for _, field := range updateFields(model) {
+		if field.Raw.Unique {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// upsertColumns upsert 冲突时覆盖的列: 可更新字段、updated_at, 软删除表还会清空 deleted_at 恢复记录
+func upsertColumns(model models.GoModel) []string {
+	var columns []string
+	for _, field := range updateFields(model) {
+		columns = append(columns, field.JsonName)
+	}
+	for _, field := range model.Fields {
+		if field.GoName == "UpdatedAt" || field.GoName == "DeletedAt" {
+			columns = append(columns, field.JsonName)
+		}
+	}
+	return columns
+}
+
+// importHeader 导入文件的表头及其对应的字段, Field 为空表示忽略该列
+type importHeader struct {
+	Header string // 小写的表头
+	Field  string // 字段 JSON 名
+	Kind   string // 单元格解析方式, 为生成代码中的 cellKind 常量名
+}
+
+// importHeaders 导入时可识别的表头: 字段 JSON 名和字段注释, 不在创建请求中的字段（主键、时间戳等）对应的列被忽略
+func importHeaders(model models.GoModel) []importHeader {
+	creatable := make(map[string]bool)
+	for _, field := range createFields(model) {
+		creatable[field.GoName] = true
+	}
+	var headers []importHeader
+	seen := make(map[string]bool)
+	add := func(header string, field models.GoField) {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" || seen[header] {
+			return
+		}
+		seen[header] = true
+		h := importHeader{Header: header}
+		if creatable[field.GoName] {
+			h.Field, h.Kind = field.JsonName, cellKind(field)
+		}
+		headers = append(headers, h)
+	}
+	// JSON 名优先, 注释与其他字段的 JSON 名或注释重复时不作为表头
+	for _, field := range model.Fields {
+		add(field.JsonName, field)
+	}
+	for _, field := range model.Fields {
+		add(field.Comment, field)
+	}
+	return headers
+}
+
+// cellKind 导入时单元格的解析方式: 数字、布尔值和 json 字段按 JSON 字面量解析, 其他（包括按字符串序列化的 bigint）按字符串
+func cellKind(field models.GoField) string {
+	base := strings.TrimPrefix(field.GoType, "*")
+	switch {
+	case field.Raw.Type == "json":
+		return "cellJSON"
+	case strings.HasSuffix(field.JsonTag, ",string"):
+		return "cellText"
+	case base == "bool":
+		return "cellBool"
+	case strings.HasPrefix(base, "int"), strings.HasPrefix(base, "uint"), strings.HasPrefix(base, "float"):
+		return "cellNumber"
+	}
+	return "cellText"
+}
+
+// cursorFields 游标分页可用的排序字段: 排除可为 NULL 的 deleted_at、可选指针字段和无法比较大小的 json、binary 字段
+func cursorFields(model models.GoModel) []models.GoField {
+	var fields []models.GoField
+	for _, field := range model.Fields {
+		if field.GoName == "DeletedAt" || field.Raw.Type == "json" || field.Raw.Type == "binary" ||
+			strings.HasPrefix(field.GoType, "*") && !field.Raw.Required {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// updateGoType 部分更新 DTO 使用指针类型, 区分未传字段和零值
+func updateGoType(field models.GoField) string {
+	if !strings.HasPrefix(field.GoType, "*") {
+		return "*" + field.GoType
+	}
+	return field.GoType
+}
+
+// updateTags 部分更新 DTO 的字段标签, 只保留类型格式和枚举校验, 完整规则在合并后的实体上校验
+func updateTags(field models.GoField) string {
+	tags := fmt.Sprintf("json:\"%s,omitempty\"", field.JsonTag)
+	var rules []string
+	if rule := typeRule(field.Raw); rule != "" {
+		rules = append(rules, rule)
+	}
+	if oneOf := buildOneOf(field.Raw); oneOf != "" {
+		rules = append(rules, oneOf)
+	}
+	if len(rules) > 0 {
+		tags += fmt.Sprintf(" binding:\"omitempty,%s\"", strings.Join(rules, ","))
+	}
+	return "`" + tags + "`"
+}
+
+// notNullField 判断字段在部分更新时是否不能置为 null: 必填且没有默认值
+func notNullField(field models.GoField) bool {
+	return strings.HasPrefix(field.ValidateTag, "required")
+}
+
+// hasDefault 判断字段是否有非零的默认值, 整体替换时省略该字段需要恢复默认值
+func hasDefault(field models.GoField) bool {
+	raw := field.Raw
+	return raw.Default != nil && !raw.IsTime() && formatValue(raw.Default) != formatValue(zeroValue(raw))
+}
+
+// resetValue 字段置为 null 或整体替换时省略后的取值: 有默认值时恢复默认值, 否则为零值
+func resetValue(field models.GoField) string {
+	raw := field.Raw
+	if !hasDefault(field) {
+		switch field.GoType {
+		case "string":
+			return `""`
+		case "int64", "float64":
+			return "0"
+		case "bool":
+			return "false"
+		case "time.Time":
+			return "time.Time{}"
+		}
+		return "nil"
+	}
+	literal := formatValue(raw.Default)
+	if raw.IsString() {
+		literal = strconv.Quote(literal)
+	}
+	if base, ok := strings.CutPrefix(field.GoType, "*"); ok {
+		return fmt.Sprintf("ptrTo[%s](%s)", base, literal)
+	}
+	return literal
+}
+
+// enumGroups 返回模型中枚举字段的常量定义
+func enumGroups(model models.GoModel) []enumGroup {
+	var groups []enumGroup
+	for _, field := range model.Fields {
+		if !hasEnum(field.Raw) {
+			continue
+		}
+		comment := field.Comment
+		if comment == "" {
+			comment = "可选值"
+		}
+		group := enumGroup{GoName: field.GoName, Comment: comment}
+		baseType := strings.TrimPrefix(field.GoType, "*")
+		for i, v := range field.Raw.Enum {
+			literal := formatValue(v)
+			if baseType == "string" {
+				literal = strconv.Quote(literal)
+			}
+			group.Consts = append(group.Consts, enumConst{
+				Name:    enumConstName(model.Name, field.JsonName, v, i),
+				Type:    baseType,
+				Literal: literal,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// keywordSearch 构建关键字搜索的 Where 参数, 搜索所有文本字段; 没有可搜索字段时返回空
+func keywordSearch(model models.GoModel) string {
+	var conditions, args []string
+	for _, f := range model.Fields {
+		if isSearchable(f.Raw) {
+			conditions = append(conditions, fmt.Sprintf("%s LIKE ?", f.JsonName))
+			args = append(args, "keyword")
+		}
+	}
+	if len(conditions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\"%s\", %s", strings.Join(conditions, " OR "), strings.Join(args, ", "))
+}
+
+// joinTables 返回所有多对多关联, 用于注册中间表模型
+func (g *Generator) joinTables() []joinTableSetup {
+	var result []joinTableSetup
+	for _, model := range g.Models {
+		for _, assoc := range model.Associations {
+			if assoc.Kind == "many2many" {
+				result = append(result, joinTableSetup{Model: model, Assoc: assoc})
+			}
+		}
+	}
+	return result
+}
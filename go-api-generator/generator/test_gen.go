@@ -0,0 +1,1419 @@
+package generator
+
+import (
+	"fmt"
+	"go-api-generator/models"
+	"math"
+	"slices"
+	"strings"
+)
+
+// generateTests 为生成的项目生成表驱动的接口测试
+// 测试基于内存 SQLite 启动 router.SetupRouter(), 校验失败用例由 schema 的 required、length、format、enum 推导
+func (g *Generator) generateTests() error {
+	if err := g.writeFile("handlers/main_test.go", g.buildTestMain()); err != nil {
+		return err
+	}
+	for _, model := range g.Models {
+		filename := fmt.Sprintf("handlers/%s_handler_test.go", strings.ToLower(model.TableName))
+		if err := g.writeFile(filename, g.buildModelTest(model)); err != nil {
+			return fmt.Errorf("写入测试文件失败 %s: %w", model.Name, err)
+		}
+	}
+
+	// 客户端测试: 通过 httptest.Server 调用真实路由
+	if err := g.writeFile("client/main_test.go", g.buildClientTestMain()); err != nil {
+		return err
+	}
+	for _, model := range g.Models {
+		filename := fmt.Sprintf("client/%s_test.go", strings.ToLower(model.TableName))
+		if err := g.writeFile(filename, g.buildClientTest(model)); err != nil {
+			return fmt.Errorf("写入客户端测试文件失败 %s: %w", model.Name, err)
+		}
+	}
+	return nil
+}
+
+// testRole 测试请求使用的角色, 公开接口或未启用认证时为空
+func (g *Generator) testRole(table, action string) string {
+	roles, public := g.authRoles(table, action)
+	switch {
+	case public:
+		return ""
+	case len(roles) > 0:
+		return roles[0]
+	default:
+		return g.Config.Auth.Roles[0]
+	}
+}
+
+// roleAllowed 判断角色能否访问指定操作, 未启用认证时总是允许
+func (g *Generator) roleAllowed(table, action, role string) bool {
+	roles, public := g.authRoles(table, action)
+	return public || len(roles) == 0 || slices.Contains(roles, role)
+}
+
+// testRoleField apiCase 中的角色字段, 无需认证时为空
+func (g *Generator) testRoleField(table, action string) string {
+	if role := g.testRole(table, action); role != "" {
+		return fmt.Sprintf(", role: %q", role)
+	}
+	return ""
+}
+
+// testRoleArg doRequest 的角色参数, 未启用认证时为空
+func (g *Generator) testRoleArg(table, action string) string {
+	if !g.authEnabled() {
+		return ""
+	}
+	return fmt.Sprintf(", %q", g.testRole(table, action))
+}
+
+// buildTestMain 构建测试公共代码: 初始化内存数据库和路由, 请求与断言辅助函数
+func (g *Generator) buildTestMain() string {
+	var sb strings.Builder
+
+	sb.WriteString(`package handlers_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+`)
+	if g.hasCompositeKey() {
+		sb.WriteString("\t\"strconv\"\n")
+	}
+	sb.WriteString(`	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+`)
+	sb.WriteString(fmt.Sprintf("\t\"%s/database\"\n", g.ModName))
+	if g.authEnabled() {
+		sb.WriteString(fmt.Sprintf("\t\"%s/middleware\"\n", g.ModName))
+	}
+	sb.WriteString(fmt.Sprintf("\t\"%s/router\"\n", g.ModName))
+	sb.WriteString(`)
+
+// testRouter 所有测试共用的路由
+var testRouter *gin.Engine
+
+// seq 生成唯一值的序号, 避免唯一索引冲突
+var seq int64
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	// 共享缓存的内存数据库, 连接池中的连接看到同一个库
+	if err := database.InitDB("file:handlers_test?mode=memory&cache=shared"); err != nil {
+		log.Fatalf("初始化测试数据库失败: %v", err)
+	}
+	testRouter = router.SetupRouter()
+	os.Exit(m.Run())
+}
+
+// nextSeq 返回下一个序号
+func nextSeq() int {
+	return int(atomic.AddInt64(&seq, 1))
+}
+
+// sampleString 构造带序号的字符串, 超过 max 时保留末尾
+func sampleString(prefix string, n, max int) string {
+	s := fmt.Sprintf("%s%d", prefix, n)
+	if max > 0 && len(s) > max {
+		s = s[len(s)-max:]
+	}
+	return s
+}
+
+// apiCase 单个接口用例
+type apiCase struct {
+	name   string
+	method string
+	path   string
+	body   any // string 原样发送, 其他值序列化为 JSON
+	status int
+`)
+	if g.authEnabled() {
+		sb.WriteString("\trole   string // 请求使用的角色, 为空时不携带令牌\n")
+	}
+	if g.usesVersion() {
+		sb.WriteString("\theader map[string]string // 额外的请求头, 如 If-Match\n")
+	}
+	sb.WriteString(`	check  func(t *testing.T, data any)
+}
+
+// runCases 按顺序执行用例并检查状态码
+func runCases(t *testing.T, cases []apiCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+`)
+	switch {
+	case g.usesVersion() && g.authEnabled():
+		sb.WriteString("\t\t\tw := doRequestHeader(t, tc.method, tc.path, tc.body, tc.header, tc.role)\n")
+	case g.usesVersion():
+		sb.WriteString("\t\t\tw := doRequestHeader(t, tc.method, tc.path, tc.body, tc.header)\n")
+	case g.authEnabled():
+		sb.WriteString("\t\t\tw := doRequest(t, tc.method, tc.path, tc.body, tc.role)\n")
+	default:
+		sb.WriteString("\t\t\tw := doRequest(t, tc.method, tc.path, tc.body)\n")
+	}
+	sb.WriteString(`			if w.Code != tc.status {
+				t.Fatalf("%s %s: 状态码 %d, 期望 %d, 响应 %s", tc.method, tc.path, w.Code, tc.status, w.Body.String())
+			}
+			if tc.check != nil {
+				tc.check(t, responseData(t, w))
+			}
+		})
+	}
+}
+
+`)
+	if g.authEnabled() {
+		sb.WriteString(`// doRequest 发送请求, role 不为空时携带该角色的令牌
+func doRequest(t *testing.T, method, path string, body any, role string) *httptest.ResponseRecorder {
+`)
+	} else {
+		sb.WriteString(`// doRequest 发送请求
+func doRequest(t *testing.T, method, path string, body any) *httptest.ResponseRecorder {
+`)
+	}
+	// 启用乐观锁时 doRequest 委托给可携带 If-Match 等请求头的 doRequestHeader
+	if g.usesVersion() {
+		if g.authEnabled() {
+			sb.WriteString(`	t.Helper()
+	return doRequestHeader(t, method, path, body, nil, role)
+}
+
+// doRequestHeader 发送带额外请求头的请求, role 不为空时携带该角色的令牌
+func doRequestHeader(t *testing.T, method, path string, body any, header map[string]string, role string) *httptest.ResponseRecorder {
+`)
+		} else {
+			sb.WriteString(`	t.Helper()
+	return doRequestHeader(t, method, path, body, nil)
+}
+
+// doRequestHeader 发送带额外请求头的请求
+func doRequestHeader(t *testing.T, method, path string, body any, header map[string]string) *httptest.ResponseRecorder {
+`)
+		}
+	}
+	sb.WriteString(`	t.Helper()
+	var payload []byte
+	switch b := body.(type) {
+	case nil:
+	case string:
+		payload = []byte(b)
+	default:
+		var err error
+		if payload, err = json.Marshal(b); err != nil {
+			t.Fatalf("序列化请求失败: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+`)
+	if g.usesVersion() {
+		sb.WriteString("\tfor key, value := range header {\n\t\treq.Header.Set(key, value)\n\t}\n")
+	}
+	if g.authEnabled() {
+		sb.WriteString("\tsetToken(t, req, role)\n")
+	}
+	sb.WriteString(`	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	return w
+}
+
+`)
+	if g.authEnabled() {
+		sb.WriteString(`// uploadFile 以 multipart 表单上传文件（字段 file）, role 不为空时携带该角色的令牌
+func uploadFile(t *testing.T, path, filename, content string, role string) *httptest.ResponseRecorder {
+`)
+	} else {
+		sb.WriteString(`// uploadFile 以 multipart 表单上传文件（字段 file）
+func uploadFile(t *testing.T, path, filename, content string) *httptest.ResponseRecorder {
+`)
+	}
+	sb.WriteString(`	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	if err == nil {
+		_, err = part.Write([]byte(content))
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		t.Fatalf("构造上传请求失败: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+`)
+	if g.authEnabled() {
+		sb.WriteString("\tsetToken(t, req, role)\n")
+	}
+	sb.WriteString(`	w := httptest.NewRecorder()
+	testRouter.ServeHTTP(w, req)
+	return w
+}
+`)
+	if g.authEnabled() {
+		sb.WriteString(`
+// setToken 为请求签发并携带 role 角色的令牌, role 为空时不携带
+func setToken(t *testing.T, req *http.Request, role string) {
+	t.Helper()
+	if role == "" {
+		return
+	}
+	token, _, err := middleware.GenerateToken(1, "test_"+role, role)
+	if err != nil {
+		t.Fatalf("签发令牌失败: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+`)
+	}
+	sb.WriteString(`
+// csvFile 由请求体构造 CSV 文件, 表头为第一条记录的字段名; 字符串（含 JSON 中按字符串传输的值）原样写入, 其他值写为 JSON 文本
+func csvFile(t *testing.T, rows ...map[string]any) string {
+	t.Helper()
+	var header []string
+	for key := range rows[0] {
+		header = append(header, key)
+	}
+	sort.Strings(header)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(header)
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for i, key := range header {
+			data, err := json.Marshal(row[key])
+			if err != nil {
+				t.Fatalf("序列化 %s 失败: %v", key, err)
+			}
+			if err := json.Unmarshal(data, &record[i]); err != nil {
+				record[i] = string(data)
+			}
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// wantFile 断言导出文件的状态码、Content-Type 和开头的内容
+func wantFile(t *testing.T, w *httptest.ResponseRecorder, contentType, prefix string) {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("状态码 %d, 期望 200, 响应 %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, contentType) {
+		t.Fatalf("Content-Type = %s, 期望 %s", got, contentType)
+	}
+	if !strings.HasPrefix(w.Body.String(), prefix) {
+		t.Fatalf("文件内容 %.80q, 期望以 %q 开头", w.Body.String(), prefix)
+	}
+}
+
+// responseData 解析统一响应中的 data
+func responseData(t *testing.T, w *httptest.ResponseRecorder) any {
+	t.Helper()
+	var resp struct {
+		Code int ` + "`json:\"code\"`" + `
+		Data any ` + "`json:\"data\"`" + `
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("解析响应失败: %v, 响应 %s", err, w.Body.String())
+	}
+	return resp.Data
+}
+
+// createdID 从创建接口的响应中读取主键
+func createdID(t *testing.T, w *httptest.ResponseRecorder, key string) int64 {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("创建失败: 状态码 %d, 响应 %s", w.Code, w.Body.String())
+	}
+	data, _ := responseData(t, w).(map[string]any)
+	id, ok := data[key].(float64)
+	if !ok {
+		t.Fatalf("响应中缺少 %s: %s", key, w.Body.String())
+	}
+	return int64(id)
+}
+`)
+	if g.hasStringKey() {
+		sb.WriteString(`
+// createdString 从创建接口的响应中读取字符串主键
+func createdString(t *testing.T, w *httptest.ResponseRecorder, key string) string {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("创建失败: 状态码 %d, 响应 %s", w.Code, w.Body.String())
+	}
+	data, _ := responseData(t, w).(map[string]any)
+	id, ok := data[key].(string)
+	if !ok || id == "" {
+		t.Fatalf("响应中缺少 %s: %s", key, w.Body.String())
+	}
+	return id
+}
+`)
+	}
+	if g.hasCompositeKey() {
+		sb.WriteString(`
+// createdKey 从创建接口的响应中读取复合主键
+func createdKey(t *testing.T, w *httptest.ResponseRecorder, columns ...string) map[string]any {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("创建失败: 状态码 %d, 响应 %s", w.Code, w.Body.String())
+	}
+	data, _ := responseData(t, w).(map[string]any)
+	key := make(map[string]any, len(columns))
+	for _, column := range columns {
+		value, ok := data[column]
+		if !ok {
+			t.Fatalf("响应中缺少 %s: %s", column, w.Body.String())
+		}
+		key[column] = value
+	}
+	return key
+}
+
+// itemPath 按主键列的顺序拼接复合主键路径, 如 3/7
+func itemPath(key map[string]any, columns ...string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		if n, ok := key[column].(float64); ok {
+			parts[i] = strconv.FormatFloat(n, 'f', -1, 64)
+		} else {
+			parts[i] = fmt.Sprint(key[column])
+		}
+	}
+	return strings.Join(parts, "/")
+}
+`)
+	}
+	sb.WriteString(`
+// wantField 断言对象字段的值
+func wantField(key string, want any) func(t *testing.T, data any) {
+	return func(t *testing.T, data any) {
+		t.Helper()
+		obj, _ := data.(map[string]any)
+		if got := fmt.Sprint(obj[key]); got != fmt.Sprint(want) {
+			t.Fatalf("%s = %s, 期望 %v", key, got, want)
+		}
+	}
+}
+
+// wantLen 断言数组的长度, 如批量接口返回的记录或出错的记录
+func wantLen(want int) func(t *testing.T, data any) {
+	return func(t *testing.T, data any) {
+		t.Helper()
+		if list, _ := data.([]any); len(list) != want {
+			t.Fatalf("长度 = %d, 期望 %d: %v", len(list), want, data)
+		}
+	}
+}
+
+// wantTotal 断言分页结果的总数
+func wantTotal(want int) func(t *testing.T, data any) {
+	return func(t *testing.T, data any) {
+		t.Helper()
+		page, _ := data.(map[string]any)
+		if got, _ := page["total"].(float64); int(got) != want {
+			t.Fatalf("total = %v, 期望 %d", page["total"], want)
+		}
+	}
+}
+
+// wantNextCursor 断言游标分页结果有下一页且未统计总数
+func wantNextCursor(t *testing.T, data any) {
+	t.Helper()
+	page, _ := data.(map[string]any)
+	if cursor, _ := page["next_cursor"].(string); cursor == "" {
+		t.Fatalf("缺少 next_cursor: %v", data)
+	}
+	if _, ok := page["total"]; ok {
+		t.Fatalf("skip_total 时不应返回 total: %v", data)
+	}
+}
+`)
+	return sb.String()
+}
+
+// buildValidRequest 构建 valid{模型} 函数: 可通过校验的创建请求（JSON 形式）, 接口测试和客户端测试共用
+// 外键字段先创建被引用的记录再取其主键, 以满足数据库的外键约束; client 为 true 时通过客户端创建
+func (g *Generator) buildValidRequest(model GoModelWrapper, client bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("// valid%s 构造可通过校验的创建%s请求, n 用于生成唯一值\n", model.Name, model.Description))
+	sb.WriteString(fmt.Sprintf("func valid%s(t *testing.T, n int) map[string]any {\n", model.Name))
+	sb.WriteString("\tt.Helper()\n")
+	sb.WriteString("\treturn map[string]any{\n")
+	for _, field := range model.Fields {
+		if value := g.validValue(model, field, client); value != "" {
+			sb.WriteString(fmt.Sprintf("\t\t%q: %s,\n", field.JsonName, value))
+		}
+	}
+	sb.WriteString("\t}\n")
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// validValue 合法请求中字段取值的 Go 表达式, 外键引用新建的记录; 不填写的字段返回空
+func (g *Generator) validValue(model GoModelWrapper, field models.GoField, client bool) string {
+	if value, ok := g.fixtureValue(model, field, client); ok {
+		return value
+	}
+	return sampleValue(model, field)
+}
+
+// fixtureRelation 返回字段作为外键所属的关系, 非外键返回 nil
+func (g *Generator) fixtureRelation(model GoModelWrapper, field models.GoField) *models.Relation {
+	for i, rel := range g.Config.Relations {
+		if rel.From == model.TableName && rel.ForeignKey == field.JsonName && g.findModel(rel.To) != nil {
+			return &g.Config.Relations[i]
+		}
+	}
+	return nil
+}
+
+// fixtureValue 外键字段在测试数据中的取值, ok 为 false 表示不是外键
+// 自引用或循环引用无法预先创建被引用的记录: 可选外键不填写, 必填外键仍使用示例值
+func (g *Generator) fixtureValue(model GoModelWrapper, field models.GoField, client bool) (value string, ok bool) {
+	rel := g.fixtureRelation(model, field)
+	if rel == nil {
+		return "", false
+	}
+	if g.referencesTable(rel.To, model.TableName, map[string]bool{}) {
+		if notNullField(field) {
+			return sampleValue(model, field), true
+		}
+		return "", true
+	}
+	parent := *g.findModel(rel.To)
+	if rel.ReferenceKey == "" || rel.ReferenceKey == pkColumn(parent) {
+		value = fmt.Sprintf("create%s(t)", parent.Name)
+		if field.Raw.Type == "bigint" {
+			// bigint 以字符串传输
+			value = fmt.Sprintf("fmt.Sprint(%s)", value)
+		}
+		return value, true
+	}
+	// 引用唯一字段: 创建被引用的记录并返回该字段的值
+	create := fmt.Sprintf("doRequest(t, http.MethodPost, %q, body%s); w.Code != http.StatusOK",
+		fmt.Sprintf("/api/v1/%ss", strings.ToLower(parent.TableName)), g.testRoleArg(parent.TableName, authCreate))
+	if client {
+		create = fmt.Sprintf("newClient(t%s).Create%s(context.Background(), decodeRequest[models.Create%sRequest](t, body)); err != nil",
+			g.testRoleArg(parent.TableName, authCreate), parent.Name, parent.Name)
+		create = "_, err := " + create
+	} else {
+		create = "w := " + create
+	}
+	return fmt.Sprintf("func() any {\n\t\t\tbody := valid%s(t, nextSeq())\n\t\t\tif %s {\n\t\t\t\tt.Fatalf(\"创建%s失败\")\n\t\t\t}\n\t\t\treturn body[%q]\n\t\t}()",
+		parent.Name, create, parent.Description, rel.ReferenceKey), true
+}
+
+// referencesTable 表 from 是否直接或间接通过外键引用表 target
+func (g *Generator) referencesTable(from, target string, seen map[string]bool) bool {
+	if from == target {
+		return true
+	}
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	for _, rel := range g.Config.Relations {
+		if rel.From == from && g.referencesTable(rel.To, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedByFixture 模型是否被其他模型的测试数据按主键引用, 是则客户端测试需要 create 辅助函数
+func (g *Generator) referencedByFixture(model GoModelWrapper) bool {
+	for _, other := range g.Models {
+		for _, field := range other.Fields {
+			if rel := g.fixtureRelation(other, field); rel != nil && rel.To == model.TableName {
+				if value, _ := g.fixtureValue(other, field, true); strings.HasPrefix(value, "create") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// buildModelTest 构建单个模型的接口测试
+func (g *Generator) buildModelTest(model GoModelWrapper) string {
+	var sb strings.Builder
+	base := fmt.Sprintf("/api/v1/%ss", strings.ToLower(model.TableName))
+	pk := pkColumn(model)
+	key := newTestKey(model, base)
+	invalid := g.invalidCreateCases(model)
+	needsStrings := false
+	for _, c := range invalid {
+		needsStrings = needsStrings || strings.Contains(c.Mutate, "strings.")
+	}
+
+	sb.WriteString("package handlers_test\n\n")
+	sb.WriteString("import (\n")
+	sb.WriteString("\t\"fmt\"\n")
+	sb.WriteString("\t\"net/http\"\n")
+	if needsStrings {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	sb.WriteString("\t\"testing\"\n")
+	sb.WriteString(")\n\n")
+
+	// 合法的创建请求
+	sb.WriteString(g.buildValidRequest(model, false))
+
+	sb.WriteString(fmt.Sprintf("// create%s 创建%s并返回主键\n", model.Name, model.Description))
+	sb.WriteString(fmt.Sprintf("func create%s(t *testing.T) %s {\n", model.Name, key.goType))
+	sb.WriteString("\tt.Helper()\n")
+	sb.WriteString(fmt.Sprintf("\tw := doRequest(t, http.MethodPost, %q, valid%s(t, nextSeq())%s)\n", base, model.Name, g.testRoleArg(model.TableName, authCreate)))
+	sb.WriteString(fmt.Sprintf("\treturn %s\n", key.created))
+	sb.WriteString("}\n\n")
+
+	// CRUD 用例
+	role := func(action string) string { return g.testRoleField(model.TableName, action) }
+	sb.WriteString(fmt.Sprintf("func Test%sCRUD(t *testing.T) {\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tid := create%s(t)\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tother1, other2 := create%s(t), create%s(t)\n", model.Name, model.Name))
+	sb.WriteString(fmt.Sprintf("\titem := %s\n\n", key.path("id")))
+	sb.WriteString("\trunCases(t, []apiCase{\n")
+	writeCase := func(name, method, path, body, status, action, check string) {
+		line := fmt.Sprintf("\t\t{name: %q, method: http.Method%s, path: %s", name, method, path)
+		if body != "" {
+			line += ", body: " + body
+		}
+		line += ", status: http.Status" + status
+		line += role(action)
+		if check != "" {
+			line += ", check: " + check
+		}
+		sb.WriteString(line + "},\n")
+	}
+	writeCase("创建时请求体格式错误", "Post", fmt.Sprintf("%q", base), `"{invalid"`, "BadRequest", authCreate, "")
+	writeCase("根据ID查询", "Get", "item", "", "OK", authRead, fmt.Sprintf("wantField(%q, %s)", pk, key.first("id")))
+	writeCase("查询不存在的ID", "Get", fmt.Sprintf("%q", base+"/"+key.missing), "", "NotFound", authRead, "")
+	if key.invalid != "" {
+		writeCase("无效的ID", "Get", fmt.Sprintf("%q", base+"/"+key.invalid), "", "BadRequest", authRead, "")
+	}
+	writeCase("分页列表", "Get", fmt.Sprintf("%q", base+"?page=1&page_size=10"), "", "OK", authRead, "")
+	if key.verb != "" {
+		writeCase("按主键多值过滤", "Get", fmt.Sprintf("fmt.Sprintf(\"%s?%s_in=%s&%s_in=%s\", id, other1)", base, pk, key.verb, pk, key.verb), "", "OK", authRead, "wantTotal(2)")
+	}
+	writeCase("游标分页", "Get", fmt.Sprintf("%q", base+"?cursor=&page_size=1&skip_total=true"), "", "OK", authRead, "wantNextCursor")
+	writeCase("无效的游标", "Get", fmt.Sprintf("%q", base+"?cursor=invalid"), "", "BadRequest", authRead, "")
+	writeCase("不支持的排序列", "Get", fmt.Sprintf("%q", base+"?order_by=not_a_column"), "", "BadRequest", authRead, "")
+	writeCase("非法的排序方向", "Get", fmt.Sprintf("%q", base+"?order=sideways"), "", "BadRequest", authRead, "")
+	if field := updatableField(model); field != nil {
+		writeCase("部分更新", "Patch", "item", fmt.Sprintf("map[string]any{%q: valid%s(t, nextSeq())[%q]}", field.JsonName, model.Name, field.JsonName), "OK", authUpdate, "")
+	}
+	for _, field := range model.Fields {
+		if !isKeyField(model, field) && hasEnum(field.Raw) {
+			writeCase(field.JsonName+" 不在枚举值中", "Patch", "item", fmt.Sprintf("map[string]any{%q: %s}", field.JsonName, invalidEnumValue(field.Raw)), "BadRequest", authUpdate, "")
+		}
+	}
+	if field := firstUpdateField(model, notNullField); field != nil {
+		writeCase(field.JsonName+" 不能为 null", "Patch", "item", fmt.Sprintf("map[string]any{%q: nil}", field.JsonName), "BadRequest", authUpdate, "")
+	}
+	if field := firstUpdateField(model, func(f models.GoField) bool { return notNullField(f) && f.GoType == "string" }); field != nil {
+		writeCase("合并后 "+field.JsonName+" 为空", "Patch", "item", fmt.Sprintf("map[string]any{%q: \"\"}", field.JsonName), "BadRequest", authUpdate, "")
+	}
+	if field := firstUpdateField(model, func(f models.GoField) bool { return !notNullField(f) }); field != nil {
+		writeCase("清空 "+field.JsonName, "Patch", "item", fmt.Sprintf("map[string]any{%q: nil}", field.JsonName), "OK", authUpdate, "")
+	}
+	writeCase("更新时没有字段", "Patch", "item", "map[string]any{}", "BadRequest", authUpdate, "")
+	writeCase("整体替换", "Put", "item", fmt.Sprintf("valid%s(t, nextSeq())", model.Name), "OK", authUpdate, "")
+	if firstUpdateField(model, notNullField) != nil {
+		writeCase("整体替换缺少必填字段", "Put", "item", "map[string]any{}", "BadRequest", authUpdate, "")
+	}
+	if g.authEnabled() {
+		if _, public := g.authRoles(model.TableName, authDelete); !public {
+			sb.WriteString(fmt.Sprintf("\t\t{name: %q, method: http.MethodDelete, path: item, status: http.StatusUnauthorized},\n", "未登录时删除"))
+		}
+	}
+	writeCase("删除", "Delete", "item", "", "OK", authDelete, "")
+	writeCase("删除后查询", "Get", "item", "", "NotFound", authRead, "")
+	if model.SoftDelete {
+		trashed := fmt.Sprintf("%q", base+"?trashed=only")
+		if key.verb != "" {
+			trashed = fmt.Sprintf("fmt.Sprintf(\"%s?trashed=only&%s_in=%s\", id)", base, pk, key.verb)
+		}
+		writeCase("回收站中的记录", "Get", trashed, "", "OK", authRead, "wantTotal(1)")
+		writeCase("非法的回收站参数", "Get", fmt.Sprintf("%q", base+"?trashed=all"), "", "BadRequest", authRead, "")
+		writeCase("恢复", "Post", `item + "/restore"`, "", "OK", authDelete, "")
+		writeCase("恢复后查询", "Get", "item", "", "OK", authRead, fmt.Sprintf("wantField(%q, %s)", pk, key.first("id")))
+		writeCase("恢复未删除的记录", "Post", `item + "/restore"`, "", "NotFound", authDelete, "")
+		writeCase("彻底删除", "Delete", `item + "/purge"`, "", "OK", authDelete, "")
+		writeCase("彻底删除后恢复", "Post", `item + "/restore"`, "", "NotFound", authDelete, "")
+	}
+	writeCase("批量删除", "Post", fmt.Sprintf("%q", base+"/batch-delete"), fmt.Sprintf("map[string]any{\"ids\": []%s{other1, other2}}", key.goType), "OK", authDelete, "")
+	writeCase("批量删除缺少 ids", "Post", fmt.Sprintf("%q", base+"/batch-delete"), "map[string]any{}", "BadRequest", authDelete, "")
+	writeCase("批量删除后查询", "Get", key.path("other1"), "", "NotFound", authRead, "")
+	sb.WriteString("\t})\n")
+	sb.WriteString("}\n")
+
+	// 批量接口用例
+	sb.WriteString(fmt.Sprintf("\nfunc Test%sBatch(t *testing.T) {\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tid := create%s(t)\n", model.Name))
+	// upsert 需要同时有创建和更新权限
+	testUpsert := len(uniqueFields(model)) > 0 && g.roleAllowed(model.TableName, authUpdate, g.testRole(model.TableName, authCreate))
+	if testUpsert {
+		sb.WriteString(fmt.Sprintf("\tupsert := valid%s(t, nextSeq())\n", model.Name))
+	}
+	sb.WriteString("\n\trunCases(t, []apiCase{\n")
+	batch := fmt.Sprintf("%q", base+"/batch")
+	items := func(values ...string) string {
+		return fmt.Sprintf("map[string]any{\"items\": []any{%s}}", strings.Join(values, ", "))
+	}
+	valid := fmt.Sprintf("valid%s(t, nextSeq())", model.Name)
+	writeCase("批量创建", "Post", batch, items(valid, valid), "OK", authCreate, "wantLen(2)")
+	writeCase("批量创建缺少 items", "Post", batch, "map[string]any{}", "BadRequest", authCreate, "")
+	writeCase("批量创建中有不合法的记录", "Post", batch, items(valid, `"invalid"`), "BadRequest", authCreate, "wantLen(1)")
+	if field := updatableField(model); field != nil {
+		data := fmt.Sprintf("map[string]any{%q: valid%s(t, nextSeq())[%q]}", field.JsonName, model.Name, field.JsonName)
+		writeCase("批量部分更新", "Patch", batch, items(fmt.Sprintf("map[string]any{\"id\": id, \"data\": %s}", data)), "OK", authUpdate, "")
+		if missing := key.missingValue(); missing != "" {
+			writeCase("批量更新不存在的记录", "Patch", batch, items(fmt.Sprintf("map[string]any{\"id\": id, \"data\": %s}", data), fmt.Sprintf("map[string]any{\"id\": %s, \"data\": %s}", missing, data)), "BadRequest", authUpdate, "wantLen(1)")
+		}
+	}
+	writeCase("批量更新时没有字段", "Patch", batch, items("map[string]any{\"id\": id, \"data\": map[string]any{}}"), "BadRequest", authUpdate, "wantLen(1)")
+	if testUpsert {
+		upsert := fmt.Sprintf("%q", base+"/upsert")
+		writeCase("upsert 插入新记录", "Put", upsert, items("upsert"), "OK", authCreate, "wantLen(1)")
+		writeCase("upsert 更新已有记录", "Put", upsert, items("upsert"), "OK", authCreate, "wantLen(1)")
+		writeCase("upsert 不支持的列", "Put", fmt.Sprintf("%q", base+"/upsert?on=not_a_column"), items("upsert"), "BadRequest", authCreate, "")
+	}
+	sb.WriteString("\t})\n")
+	sb.WriteString("}\n")
+
+	// 导入导出用例
+	sb.WriteString(g.buildImportExportTest(model, base))
+
+	// 空值过滤用例
+	sb.WriteString(g.buildNullFilterTest(model, key, base))
+
+	// 乐观锁用例
+	if model.Version {
+		sb.WriteString(g.buildVersionTest(model, key))
+	}
+
+	// 创建校验用例
+	if len(invalid) > 0 {
+		sb.WriteString(fmt.Sprintf("\nfunc Test%sCreateValidation(t *testing.T) {\n", model.Name))
+		sb.WriteString("\ttests := []struct {\n")
+		sb.WriteString("\t\tname   string\n")
+		sb.WriteString("\t\tmutate func(body map[string]any)\n")
+		sb.WriteString("\t}{\n")
+		for _, c := range invalid {
+			sb.WriteString(fmt.Sprintf("\t\t{%q, func(body map[string]any) { %s }},\n", c.Name, c.Mutate))
+		}
+		sb.WriteString("\t}\n\n")
+		sb.WriteString("\tfor _, tt := range tests {\n")
+		sb.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+		sb.WriteString(fmt.Sprintf("\t\t\tbody := valid%s(t, nextSeq())\n", model.Name))
+		sb.WriteString("\t\t\ttt.mutate(body)\n")
+		sb.WriteString(fmt.Sprintf("\t\t\tw := doRequest(t, http.MethodPost, %q, body%s)\n", base, g.testRoleArg(model.TableName, authCreate)))
+		sb.WriteString("\t\t\tif w.Code != http.StatusBadRequest {\n")
+		sb.WriteString("\t\t\t\tt.Fatalf(\"状态码 %d, 期望 400, 响应 %s\", w.Code, w.Body.String())\n")
+		sb.WriteString("\t\t\t}\n")
+		sb.WriteString("\t\t})\n")
+		sb.WriteString("\t}\n")
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// buildNullFilterTest 构建空值过滤用例: 创建时不填写可选字段, {字段}_null=true 能查到该记录
+// 取第一个有空值过滤、没有默认值且合法请求中有取值的字段, 没有该字段或复合主键时不生成
+func (g *Generator) buildNullFilterTest(model GoModelWrapper, key testKey, base string) string {
+	if key.verb == "" {
+		return ""
+	}
+	var field *models.GoField
+	for _, f := range g.listFilters(model) {
+		if f.Op != filterNull {
+			continue
+		}
+		if gf := findField(model, f.Column); gf != nil && gf.Raw.Default == nil && g.validValue(model, *gf, false) != "" {
+			field = gf
+			break
+		}
+	}
+	if field == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	pk := pkColumn(model)
+	sb.WriteString(fmt.Sprintf("\nfunc Test%sNullFilter(t *testing.T) {\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tbody := valid%s(t, nextSeq())\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tdelete(body, %q)\n", field.JsonName))
+	sb.WriteString(fmt.Sprintf("\tw := doRequest(t, http.MethodPost, %q, body%s)\n", base, g.testRoleArg(model.TableName, authCreate)))
+	sb.WriteString(fmt.Sprintf("\tid, other := %s, create%s(t)\n\n", key.created, model.Name))
+	sb.WriteString("\trunCases(t, []apiCase{\n")
+	for _, c := range []struct{ name, id, value, total string }{
+		{"未填写的 " + field.JsonName + " 为空", "id", "true", "1"},
+		{"未填写的 " + field.JsonName + " 不满足非空条件", "id", "false", "0"},
+		{"已填写的 " + field.JsonName + " 不为空", "other", "false", "1"},
+		{"已填写的 " + field.JsonName + " 不满足为空条件", "other", "true", "0"},
+	} {
+		path := fmt.Sprintf("fmt.Sprintf(\"%s?%s_in=%s&%s_null=%s\", %s)", base, pk, key.verb, field.JsonName, c.value, c.id)
+		sb.WriteString(fmt.Sprintf("\t\t{name: %q, method: http.MethodGet, path: %s, status: http.StatusOK%s, check: wantTotal(%s)},\n",
+			c.name, path, g.testRoleField(model.TableName, authRead), c.total))
+	}
+	sb.WriteString("\t})\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// buildVersionTest 构建乐观锁用例: If-None-Match 返回 304, If-Match 版本不一致时更新和删除返回 412
+func (g *Generator) buildVersionTest(model GoModelWrapper, key testKey) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nfunc Test%sVersion(t *testing.T) {\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tid := create%s(t)\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\titem := %s\n\n", key.path("id")))
+	sb.WriteString("\trunCases(t, []apiCase{\n")
+	replace := fmt.Sprintf("valid%s(t, nextSeq())", model.Name)
+	for _, c := range []struct{ name, method, header, etag, body, status, action, check string }{
+		{"新记录的版本号为 1", "Get", "", "", "", "OK", authRead, `wantField("version", 1)`},
+		{"版本未变化时返回 304", "Get", "If-None-Match", "1", "", "NotModified", authRead, ""},
+		{"版本不一致时整体替换失败", "Put", "If-Match", "0", replace, "PreconditionFailed", authUpdate, ""},
+		{"版本一致时整体替换", "Put", "If-Match", "1", replace, "OK", authUpdate, ""},
+		{"更新后版本号加 1", "Get", "", "", "", "OK", authRead, `wantField("version", 2)`},
+		{"版本变化后返回最新记录", "Get", "If-None-Match", "1", "", "OK", authRead, ""},
+		{"版本不一致时删除失败", "Delete", "If-Match", "1", "", "PreconditionFailed", authDelete, ""},
+		{"版本一致时删除", "Delete", "If-Match", "2", "", "OK", authDelete, ""},
+	} {
+		line := fmt.Sprintf("\t\t{name: %q, method: http.Method%s, path: item", c.name, c.method)
+		if c.header != "" {
+			line += fmt.Sprintf(", header: map[string]string{%q: %q}", c.header, `"`+c.etag+`"`)
+		}
+		if c.body != "" {
+			line += ", body: " + c.body
+		}
+		line += ", status: http.Status" + c.status + g.testRoleField(model.TableName, c.action)
+		if c.check != "" {
+			line += ", check: " + c.check
+		}
+		sb.WriteString(line + "},\n")
+	}
+	sb.WriteString("\t})\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// buildImportExportTest 构建导入导出用例: 导出 CSV 和 Excel, 导入合法文件, 以及不合法的行、表头和格式
+func (g *Generator) buildImportExportTest(model GoModelWrapper, base string) string {
+	var sb strings.Builder
+	read := g.testRoleArg(model.TableName, authRead)
+	create := g.testRoleArg(model.TableName, authCreate)
+	valid := fmt.Sprintf("valid%s(t, nextSeq())", model.Name)
+
+	sb.WriteString(fmt.Sprintf("\nfunc Test%sImportExport(t *testing.T) {\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tcreate%s(t)\n\n", model.Name))
+	sb.WriteString(fmt.Sprintf("\tw := doRequest(t, http.MethodGet, %q, nil%s)\n", base+"/export", read))
+	sb.WriteString(fmt.Sprintf("\twantFile(t, w, \"text/csv\", %q)\n", "\uFEFF"+model.Fields[0].JsonName+","))
+	sb.WriteString(fmt.Sprintf("\tw = doRequest(t, http.MethodGet, %q, nil%s)\n", base+"/export?format=xlsx", read))
+	sb.WriteString("\twantFile(t, w, \"application/vnd.openxmlformats\", \"PK\")\n\n")
+
+	sb.WriteString("\trunCases(t, []apiCase{\n")
+	for _, c := range []struct{ name, query string }{
+		{"导出不支持的格式", "?format=pdf"},
+		{"导出时不支持的排序列", "?order_by=not_a_column"},
+	} {
+		sb.WriteString(fmt.Sprintf("\t\t{name: %q, method: http.MethodGet, path: %q, status: http.StatusBadRequest%s},\n",
+			c.name, base+"/export"+c.query, g.testRoleField(model.TableName, authRead)))
+	}
+	sb.WriteString("\t})\n\n")
+
+	// 必填字段留空的行校验失败, 没有必填字段时不生成该用例;
+	// 只有一列时留空的行是空行, CSV 读取时跳过, 同样不生成
+	var required *models.GoField
+	columns := 0
+	for _, field := range createFields(model) {
+		if g.validValue(model, field, false) != "" {
+			columns++
+		}
+	}
+	for _, field := range createFields(model) {
+		if columns > 1 && notNullField(field) && sampleValue(model, field) != "" {
+			required = &field
+			break
+		}
+	}
+	if required != nil {
+		sb.WriteString(fmt.Sprintf("\tinvalid := %s\n", valid))
+		sb.WriteString(fmt.Sprintf("\tinvalid[%q] = \"\"\n", required.JsonName))
+	}
+	filename := strings.ToLower(model.TableName) + "s"
+	sb.WriteString("\ttests := []struct {\n")
+	sb.WriteString("\t\tname     string\n")
+	sb.WriteString("\t\tfilename string\n")
+	sb.WriteString("\t\tcontent  string\n")
+	sb.WriteString("\t\tstatus   int\n")
+	sb.WriteString("\t\tcheck    func(t *testing.T, data any)\n")
+	sb.WriteString("\t}{\n")
+	sb.WriteString(fmt.Sprintf("\t\t{\"导入\", %q, csvFile(t, %s, %s), http.StatusOK, wantField(\"count\", 2)},\n", filename+".csv", valid, valid))
+	if required != nil {
+		sb.WriteString(fmt.Sprintf("\t\t{\"导入时有不合法的行\", %q, csvFile(t, %s, invalid), http.StatusBadRequest, wantLen(1)},\n", filename+".csv", valid))
+	}
+	sb.WriteString(fmt.Sprintf("\t\t{\"导入无法识别的列\", %q, \"not_a_column\\n1\\n\", http.StatusBadRequest, nil},\n", filename+".csv"))
+	sb.WriteString(fmt.Sprintf("\t\t{\"导入不支持的文件格式\", %q, csvFile(t, %s), http.StatusBadRequest, nil},\n", filename+".txt", valid))
+	sb.WriteString("\t}\n\n")
+	sb.WriteString("\tfor _, tt := range tests {\n")
+	sb.WriteString("\t\tt.Run(tt.name, func(t *testing.T) {\n")
+	sb.WriteString(fmt.Sprintf("\t\t\tw := uploadFile(t, %q, tt.filename, tt.content%s)\n", base+"/import", create))
+	sb.WriteString("\t\t\tif w.Code != tt.status {\n")
+	sb.WriteString("\t\t\t\tt.Fatalf(\"状态码 %d, 期望 %d, 响应 %s\", w.Code, tt.status, w.Body.String())\n")
+	sb.WriteString("\t\t\t}\n")
+	sb.WriteString("\t\t\tif tt.check != nil {\n")
+	sb.WriteString("\t\t\t\ttt.check(t, responseData(t, w))\n")
+	sb.WriteString("\t\t\t}\n")
+	sb.WriteString("\t\t})\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// testKey 测试中主键的类型、读取方式和路径写法
+type testKey struct {
+	goType  string                // create 辅助函数返回的主键类型
+	created string                // 从创建响应中读取主键的表达式
+	missing string                // 不存在的主键路径
+	invalid string                // 格式错误的主键路径, 字符串主键没有非法值时为空
+	verb    string                // 主键多值过滤的格式化动词, 复合主键不生成该用例时为空
+	path    func(v string) string // 单条记录路径的表达式
+	first   func(v string) string // 第一个主键字段值的表达式
+}
+
+// missingValue 请求体中不存在的主键值, 复合主键返回空
+func (k testKey) missingValue() string {
+	switch k.goType {
+	case "int64":
+		return k.missing
+	case "string":
+		return fmt.Sprintf("%q", k.missing)
+	}
+	return ""
+}
+
+// newTestKey 根据主键类型构建测试中的主键写法: 整数、字符串（含 UUID）或复合主键
+func newTestKey(model GoModelWrapper, base string) testKey {
+	fields := keyFields(model)
+	self := func(v string) string { return v }
+	if isCompositeKey(model) {
+		var columns, missing, invalid []string
+		hasInvalid := false
+		for _, f := range fields {
+			columns = append(columns, fmt.Sprintf("%q", f.JsonName))
+			missing = append(missing, missingKeyValue(f))
+			// 第一个可校验格式的字段使用非法值, 其余字段使用合法值
+			if bad := invalidKeyValue(f); bad != "" && !hasInvalid {
+				invalid = append(invalid, bad)
+				hasInvalid = true
+			} else {
+				invalid = append(invalid, missingKeyValue(f))
+			}
+		}
+		key := testKey{
+			goType:  "map[string]any",
+			created: fmt.Sprintf("createdKey(t, w, %s)", strings.Join(columns, ", ")),
+			missing: strings.Join(missing, "/"),
+			path: func(v string) string {
+				return fmt.Sprintf("%q + itemPath(%s, %s)", base+"/", v, strings.Join(columns, ", "))
+			},
+			first: func(v string) string { return fmt.Sprintf("%s[%q]", v, fields[0].JsonName) },
+		}
+		if hasInvalid {
+			key.invalid = strings.Join(invalid, "/")
+		}
+		return key
+	}
+
+	f := fields[0]
+	if keyType(model) == "int64" {
+		return testKey{
+			goType:  "int64",
+			created: fmt.Sprintf("createdID(t, w, %q)", f.JsonName),
+			missing: missingKeyValue(f),
+			invalid: invalidKeyValue(f),
+			verb:    "%d",
+			path:    func(v string) string { return fmt.Sprintf("fmt.Sprintf(\"%s/%%d\", %s)", base, v) },
+			first:   self,
+		}
+	}
+	return testKey{
+		goType:  "string",
+		created: fmt.Sprintf("createdString(t, w, %q)", f.JsonName),
+		missing: missingKeyValue(f),
+		invalid: invalidKeyValue(f),
+		verb:    "%s",
+		path:    func(v string) string { return fmt.Sprintf("%q + %s", base+"/", v) },
+		first:   self,
+	}
+}
+
+// missingKeyValue 主键字段不存在的取值
+func missingKeyValue(f models.GoField) string {
+	switch pathParser(f) {
+	case "pathInt64":
+		return "999999999"
+	case "pathUUID":
+		return missingUUID
+	default:
+		return "missing"
+	}
+}
+
+// invalidKeyValue 主键字段格式错误的取值, 任意字符串都合法时为空
+func invalidKeyValue(f models.GoField) string {
+	switch pathParser(f) {
+	case "pathInt64":
+		return "abc"
+	case "pathUUID":
+		return "not-a-uuid"
+	default:
+		return ""
+	}
+}
+
+// hasStringKey 判断是否有模型使用字符串单一主键
+func (g *Generator) hasStringKey() bool {
+	for _, model := range g.Models {
+		if !isCompositeKey(model) && keyType(model) == "string" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCompositeKey 判断是否有模型使用复合主键
+func (g *Generator) hasCompositeKey() bool {
+	for _, model := range g.Models {
+		if isCompositeKey(model) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidCase 创建接口的校验失败用例
+type invalidCase struct {
+	Name   string // 用例名
+	Mutate string // 修改合法请求体的语句
+}
+
+// invalidCreateCases 根据字段的 required、length、format、enum 推导校验失败用例
+func (g *Generator) invalidCreateCases(model GoModelWrapper) []invalidCase {
+	var cases []invalidCase
+	for _, field := range model.Fields {
+		raw := field.Raw
+		if raw.Name == "" || sampleValue(model, field) == "" {
+			continue
+		}
+		key := fmt.Sprintf("%q", field.JsonName)
+
+		if strings.HasPrefix(field.ValidateTag, "required") {
+			cases = append(cases, invalidCase{"缺少必填字段 " + field.JsonName, fmt.Sprintf("delete(body, %s)", key)})
+		}
+		if raw.Type == "string" && raw.Length > 0 && !hasEnum(raw) {
+			var value string
+			switch raw.Format {
+			case "email":
+				value = fmt.Sprintf("strings.Repeat(\"a\", %d) + \"@example.com\"", raw.Length)
+			case "url":
+				value = fmt.Sprintf("\"https://example.com/\" + strings.Repeat(\"a\", %d)", raw.Length)
+			case "uuid":
+			default:
+				value = fmt.Sprintf("strings.Repeat(\"a\", %d)", raw.Length+1)
+			}
+			if value != "" {
+				cases = append(cases, invalidCase{fmt.Sprintf("%s 超过最大长度 %d", field.JsonName, raw.Length), fmt.Sprintf("body[%s] = %s", key, value)})
+			}
+		}
+		switch raw.Format {
+		case "email", "url", "uuid":
+			cases = append(cases, invalidCase{fmt.Sprintf("%s 不是合法的 %s", field.JsonName, raw.Format), fmt.Sprintf("body[%s] = %q", key, "not-a-"+raw.Format)})
+		}
+		switch {
+		case raw.Type == "binary" && raw.Length > 0:
+			cases = append(cases, invalidCase{fmt.Sprintf("%s 超过最大字节数 %d", field.JsonName, raw.Length), fmt.Sprintf("body[%s] = make([]byte, %d)", key, raw.Length+1)})
+		case raw.Type == "bigint":
+			cases = append(cases, invalidCase{field.JsonName + " 不是字符串形式的整数", fmt.Sprintf("body[%s] = 12", key)})
+		case raw.Type == "time":
+			cases = append(cases, invalidCase{field.JsonName + " 不是合法的时间", fmt.Sprintf("body[%s] = %q", key, "25:00:00")})
+		case raw.IsDecimalText():
+			cases = append(cases, invalidCase{field.JsonName + " 不是合法的小数", fmt.Sprintf("body[%s] = %q", key, "abc")})
+			if raw.Scale > 0 {
+				cases = append(cases, invalidCase{fmt.Sprintf("%s 小数位超过 %d 位", field.JsonName, raw.Scale), fmt.Sprintf("body[%s] = %q", key, "1."+strings.Repeat("1", raw.Scale+1))})
+			}
+		}
+		if hasEnum(raw) {
+			cases = append(cases, invalidCase{field.JsonName + " 不在枚举值中", fmt.Sprintf("body[%s] = %s", key, invalidEnumValue(raw))})
+		}
+	}
+	return cases
+}
+
+// sampleValue 字段合法取值的 Go 表达式, 可引用序号 n; 自增主键、自动生成的 UUID 主键和公共时间字段返回空
+func sampleValue(model GoModelWrapper, field models.GoField) string {
+	raw := field.Raw
+	if raw.Name == "" || raw.AutoIncrement || isUUIDKey(model, field) {
+		return ""
+	}
+	if hasEnum(raw) {
+		if raw.IsString() {
+			return fmt.Sprintf("%q", formatValue(raw.Enum[0]))
+		}
+		return formatValue(raw.Enum[0])
+	}
+
+	switch {
+	case raw.Type == "number" || raw.IsCents():
+		return "n"
+	case raw.Type == "bigint":
+		// 超过 2^53 的值验证字符串传输不丢失精度
+		return "fmt.Sprint(9007199254740993 + n)"
+	case raw.Type == "float":
+		return "float64(n) + 0.5"
+	case raw.Type == "boolean":
+		return "true"
+	case raw.IsTime():
+		return `"2024-01-02T15:04:05Z"`
+	case raw.Type == "time":
+		return `fmt.Sprintf("09:%02d:00", n%60)`
+	case raw.IsDecimalText():
+		return sampleDecimal(raw)
+	case raw.Type == "json":
+		return `map[string]any{"seq": n, "tags": []string{"a", "b"}}`
+	case raw.Type == "binary":
+		return fmt.Sprintf("[]byte(sampleString(%q, n, %d))", field.JsonName+"_", raw.Length)
+	}
+	switch raw.Format {
+	case "email":
+		return `fmt.Sprintf("user%d@example.com", n)`
+	case "url":
+		return `fmt.Sprintf("https://example.com/%d", n)`
+	case "uuid":
+		return `fmt.Sprintf("00000000-0000-4000-8000-%012d", n)`
+	}
+	length := 0
+	if raw.Type == "string" {
+		length = raw.Length
+	}
+	return fmt.Sprintf("sampleString(%q, n, %d)", field.JsonName+"_", length)
+}
+
+// sampleDecimal 字符串存储的 decimal 合法取值, 整数部分随序号变化以满足唯一约束
+func sampleDecimal(field models.Field) string {
+	frac := ""
+	if field.Scale > 0 {
+		frac = ".5" + strings.Repeat("0", field.Scale-1)
+	}
+	digits := min(field.DecimalPrecision()-field.Scale, 6)
+	if digits == 0 {
+		return fmt.Sprintf("%q", "0"+frac)
+	}
+	return fmt.Sprintf("fmt.Sprintf(\"%%d%s\", n%%%d)", frac, int(math.Pow10(digits)))
+}
+
+// invalidEnumValue 不在枚举值中的取值
+func invalidEnumValue(field models.Field) string {
+	if field.IsString() {
+		return `"__invalid__"`
+	}
+	return "987654"
+}
+
+// updatableField 更新用例修改的字段: 第一个非主键业务字段
+func updatableField(model GoModelWrapper) *models.GoField {
+	return firstUpdateField(model, func(models.GoField) bool { return true })
+}
+
+// firstUpdateField 返回第一个满足条件的可更新配置字段, 不包含公共字段和主键
+func firstUpdateField(model GoModelWrapper, match func(models.GoField) bool) *models.GoField {
+	for i, field := range model.Fields {
+		if field.Raw.Name != "" && !isKeyField(model, field) && match(field) {
+			return &model.Fields[i]
+		}
+	}
+	return nil
+}
+
+// buildClientTestMain 构建客户端测试公共代码: 内存数据库 + httptest.Server 承载真实路由, 客户端构造和错误断言
+func (g *Generator) buildClientTestMain() string {
+	var sb strings.Builder
+
+	sb.WriteString(`package client_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+`)
+	sb.WriteString(fmt.Sprintf("\t\"%s/client\"\n", g.ModName))
+	sb.WriteString(fmt.Sprintf("\t\"%s/database\"\n", g.ModName))
+	if g.authEnabled() {
+		sb.WriteString(fmt.Sprintf("\t\"%s/middleware\"\n", g.ModName))
+	}
+	sb.WriteString(fmt.Sprintf("\t\"%s/router\"\n", g.ModName))
+	sb.WriteString(`)
+
+// testServer 所有测试共用的服务
+var testServer *httptest.Server
+
+// seq 生成唯一值的序号, 避免唯一索引冲突
+var seq int64
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	// 共享缓存的内存数据库, 连接池中的连接看到同一个库
+	if err := database.InitDB("file:client_test?mode=memory&cache=shared"); err != nil {
+		log.Fatalf("初始化测试数据库失败: %v", err)
+	}
+	testServer = httptest.NewServer(router.SetupRouter())
+	code := m.Run()
+	testServer.Close()
+	os.Exit(code)
+}
+
+// nextSeq 返回下一个序号
+func nextSeq() int {
+	return int(atomic.AddInt64(&seq, 1))
+}
+
+// sampleString 构造带序号的字符串, 超过 max 时保留末尾
+func sampleString(prefix string, n, max int) string {
+	s := fmt.Sprintf("%s%d", prefix, n)
+	if max > 0 && len(s) > max {
+		s = s[len(s)-max:]
+	}
+	return s
+}
+`)
+	if g.authEnabled() {
+		sb.WriteString(`
+// newClient 创建访问测试服务的客户端, role 不为空时携带该角色的令牌
+func newClient(t *testing.T, role string) *client.Client {
+	t.Helper()
+	if role == "" {
+		return client.New(testServer.URL)
+	}
+	token, _, err := middleware.GenerateToken(1, "test_"+role, role)
+	if err != nil {
+		t.Fatalf("签发令牌失败: %v", err)
+	}
+	return client.New(testServer.URL, client.WithToken(token))
+}
+`)
+	} else {
+		sb.WriteString(`
+// newClient 创建访问测试服务的客户端
+func newClient(t *testing.T) *client.Client {
+	t.Helper()
+	return client.New(testServer.URL)
+}
+`)
+	}
+	sb.WriteString(`
+// decodeRequest 把 JSON 形式的请求体转换为请求 DTO, 与接口测试使用同样的样例数据
+func decodeRequest[T any](t *testing.T, body map[string]any) T {
+	t.Helper()
+	var req T
+	data, err := json.Marshal(body)
+	if err == nil {
+		err = json.Unmarshal(data, &req)
+	}
+	if err != nil {
+		t.Fatalf("构造请求失败: %v", err)
+	}
+	return req
+}
+
+// wantJSONField 断言实体序列化后的字段与 want 的 JSON 形式一致
+func wantJSONField(t *testing.T, entity any, key string, want any) {
+	t.Helper()
+	data, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatalf("序列化实体失败: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("解析实体失败: %v", err)
+	}
+	expected, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("序列化期望值失败: %v", err)
+	}
+	if string(fields[key]) != string(expected) {
+		t.Fatalf("字段 %s = %s, 期望 %s", key, fields[key], expected)
+	}
+}
+
+// wantAPIError 断言 err 为指定状态码的 *client.APIError, 且能用 errors.Is 匹配 target
+func wantAPIError(t *testing.T, err error, status int, target error) {
+	t.Helper()
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("错误 %v (%T), 期望 *client.APIError", err, err)
+	}
+	if apiErr.StatusCode != status || !errors.Is(err, target) {
+		t.Fatalf("错误 %v, 期望状态码 %d (%v)", err, status, target)
+	}
+	if apiErr.Message == "" {
+		t.Fatalf("错误 %v 缺少响应中的 message", err)
+	}
+}
+`)
+	return sb.String()
+}
+
+// buildClientTest 构建单个模型的客户端测试: 创建、查询、列表、部分更新, 以及 404/400/401 的错误解析
+func (g *Generator) buildClientTest(model GoModelWrapper) string {
+	var sb strings.Builder
+	plural := model.Name + "s"
+
+	sb.WriteString("package client_test\n\n")
+	sb.WriteString("import (\n")
+	sb.WriteString("\t\"context\"\n")
+	sb.WriteString("\t\"fmt\"\n")
+	sb.WriteString("\t\"net/http\"\n")
+	sb.WriteString("\t\"testing\"\n\n")
+	sb.WriteString(fmt.Sprintf("\t\"%s/client\"\n", g.ModName))
+	sb.WriteString(fmt.Sprintf("\t\"%s/models\"\n", g.ModName))
+	sb.WriteString(")\n\n")
+	sb.WriteString(g.buildValidRequest(model, true))
+	if g.referencedByFixture(model) {
+		sb.WriteString(fmt.Sprintf("// create%s 创建%s并返回主键\n", model.Name, model.Description))
+		sb.WriteString(fmt.Sprintf("func create%s(t *testing.T) %s {\n", model.Name, keyType(model)))
+		sb.WriteString("\tt.Helper()\n")
+		sb.WriteString(fmt.Sprintf("\tcreated, err := newClient(t%s).Create%s(context.Background(), decodeRequest[models.Create%sRequest](t, valid%s(t, nextSeq())))\n",
+			g.testRoleArg(model.TableName, authCreate), model.Name, model.Name, model.Name))
+		sb.WriteString(fmt.Sprintf("\tif err != nil {\n\t\tt.Fatalf(\"创建%s失败: %%v\", err)\n\t}\n", model.Description))
+		sb.WriteString(fmt.Sprintf("\treturn %s\n", clientKey(model, "created")))
+		sb.WriteString("}\n\n")
+	}
+
+	// 每种操作使用有权限的角色; 未启用认证时共用一个客户端
+	clients := map[string]string{authCreate: "c", authRead: "c", authUpdate: "c"}
+	sb.WriteString(fmt.Sprintf("func Test%sClient(t *testing.T) {\n", model.Name))
+	sb.WriteString("\tctx := context.Background()\n")
+	if g.authEnabled() {
+		clients = map[string]string{authCreate: "creator", authRead: "reader", authUpdate: "updater"}
+		for _, action := range []string{authCreate, authRead, authUpdate} {
+			sb.WriteString(fmt.Sprintf("\t%s := newClient(t, %q)\n", clients[action], g.testRole(model.TableName, action)))
+		}
+	} else {
+		sb.WriteString("\tc := newClient(t)\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("\tcreated, err := %s.Create%s(ctx, decodeRequest[models.Create%sRequest](t, valid%s(t, nextSeq())))\n",
+		clients[authCreate], model.Name, model.Name, model.Name))
+	sb.WriteString("\tif err != nil {\n\t\tt.Fatalf(\"创建失败: %v\", err)\n\t}\n")
+	sb.WriteString(fmt.Sprintf("\tid := %s\n\n", clientKey(model, "created")))
+
+	sb.WriteString(fmt.Sprintf("\tgot, err := %s.Get%s(ctx, id)\n", clients[authRead], model.Name))
+	sb.WriteString("\tif err != nil {\n\t\tt.Fatalf(\"查询失败: %v\", err)\n\t}\n")
+	gotKey := clientKey(model, "got")
+	if isCompositeKey(model) {
+		// if 语句中的复合字面量需要加括号
+		gotKey = "(" + gotKey + ")"
+	}
+	sb.WriteString(fmt.Sprintf("\tif key := %s; key != id {\n", gotKey))
+	sb.WriteString("\t\tt.Fatalf(\"查询结果的主键为 %v, 期望 %v\", key, id)\n")
+	sb.WriteString("\t}\n\n")
+
+	sb.WriteString(fmt.Sprintf("\tpage, err := %s.List%s(ctx, models.Query%sParams{Page: 1, PageSize: 10})\n", clients[authRead], plural, model.Name))
+	sb.WriteString("\tif err != nil {\n\t\tt.Fatalf(\"列表查询失败: %v\", err)\n\t}\n")
+	sb.WriteString("\tif page.Total == nil || *page.Total == 0 || len(page.List) == 0 {\n")
+	sb.WriteString("\t\tt.Fatalf(\"列表为空: %+v\", page)\n")
+	sb.WriteString("\t}\n\n")
+
+	if field := updatableField(model); field != nil {
+		sb.WriteString(fmt.Sprintf("\twant := valid%s(t, nextSeq())[%q]\n", model.Name, field.JsonName))
+		sb.WriteString(fmt.Sprintf("\tif err := %s.Update%s(ctx, id, decodeRequest[models.Update%sRequest](t, map[string]any{%q: want})); err != nil {\n",
+			clients[authUpdate], model.Name, model.Name, field.JsonName))
+		sb.WriteString("\t\tt.Fatalf(\"部分更新失败: %v\", err)\n")
+		sb.WriteString("\t}\n")
+		sb.WriteString(fmt.Sprintf("\tif got, err = %s.Get%s(ctx, id); err != nil {\n", clients[authRead], model.Name))
+		sb.WriteString("\t\tt.Fatalf(\"查询失败: %v\", err)\n")
+		sb.WriteString("\t}\n")
+		sb.WriteString(fmt.Sprintf("\twantJSONField(t, got, %q, want)\n\n", field.JsonName))
+	}
+
+	// 错误响应解析为 *client.APIError
+	sb.WriteString(fmt.Sprintf("\t_, err = %s.Get%s(ctx, %s)\n", clients[authRead], model.Name, clientMissingKey(model)))
+	sb.WriteString("\twantAPIError(t, err, http.StatusNotFound, client.ErrNotFound)\n")
+	for _, field := range createFields(model) {
+		if notNullField(field) && sampleValue(model, field) != "" {
+			sb.WriteString(fmt.Sprintf("\t_, err = %s.Create%s(ctx, models.Create%sRequest{})\n", clients[authCreate], model.Name, model.Name))
+			sb.WriteString("\twantAPIError(t, err, http.StatusBadRequest, client.ErrBadRequest)\n")
+			break
+		}
+	}
+	if g.authEnabled() && g.testRole(model.TableName, authCreate) != "" {
+		sb.WriteString(fmt.Sprintf("\t_, err = newClient(t, \"\").Create%s(ctx, decodeRequest[models.Create%sRequest](t, valid%s(t, nextSeq())))\n", model.Name, model.Name, model.Name))
+		sb.WriteString("\twantAPIError(t, err, http.StatusUnauthorized, client.ErrUnauthorized)\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// clientKey 客户端测试中由实体 v 构造主键: 单一主键为主键字段, 复合主键为 models.XxxKey
+func clientKey(model GoModelWrapper, v string) string {
+	fields := keyFields(model)
+	if !isCompositeKey(model) {
+		return v + "." + fields[0].GoName
+	}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s: %s.%s", f.GoName, v, f.GoName)
+	}
+	return fmt.Sprintf("models.%sKey{%s}", model.Name, strings.Join(parts, ", "))
+}
+
+// clientMissingKey 客户端测试中不存在的主键
+func clientMissingKey(model GoModelWrapper) string {
+	literal := func(f models.GoField) string {
+		if pathParser(f) == "pathInt64" {
+			return missingKeyValue(f)
+		}
+		return fmt.Sprintf("%q", missingKeyValue(f))
+	}
+	fields := keyFields(model)
+	if !isCompositeKey(model) {
+		return literal(fields[0])
+	}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s: %s", f.GoName, literal(f))
+	}
+	return fmt.Sprintf("models.%sKey{%s}", model.Name, strings.Join(parts, ", "))
+}
@@ -1,19 +1,31 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"go-api-generator/config"
 	"go-api-generator/generator"
 	"log"
 	"os"
+	"strings"
 )
 
 func main() {
+	// 子命令: introspect 从已有数据库生成配置
+	if len(os.Args) > 1 && os.Args[1] == "introspect" {
+		introspect(os.Args[2:])
+		return
+	}
+
 	// 命令行参数
 	configFile := flag.String("config", "examples/schema.json", "JSON配置文件路径")
 	outputDir := flag.String("output", "output", "输出目录")
 	modName := flag.String("mod", "generated-api", "生成项目的Go Module名称")
+	dryRun := flag.Bool("dry-run", false, "只显示将要变更的文件, 不写入磁盘")
+	dbType := flag.String("db", generator.DialectSQLite, "目标数据库: "+strings.Join(generator.Dialects, " / "))
+	prevFile := flag.String("prev", "", "上一版本的JSON配置文件, 用于生成迁移脚本（默认读取输出目录中的快照）")
+	templatesDir := flag.String("templates", "", "自定义模板目录, 其中的同名文件覆盖内置模板: "+strings.Join(generator.TemplateNames(), ", "))
 	flag.Parse()
 
 	fmt.Println("╔══════════════════════════════════════════════╗")
@@ -42,9 +54,22 @@ func main() {
 
 	// 第2步: 代码生成
 	gen := generator.NewGenerator(schemaConfig, *outputDir, *modName)
+	gen.DryRun = *dryRun
+	gen.Dialect = *dbType
+	gen.TemplateDir = *templatesDir
+	if *prevFile != "" {
+		prevConfig, err := parser.ParseFile(*prevFile)
+		if err != nil {
+			log.Fatalf("❌ 解析上一版本配置失败: %v", err)
+		}
+		gen.PrevConfig = prevConfig
+	}
 	if err := gen.Generate(); err != nil {
 		log.Fatalf("❌ 代码生成失败: %v", err)
 	}
+	if *dryRun {
+		return
+	}
 
 	fmt.Println()
 	fmt.Println("╔══════════════════════════════════════════════╗")
@@ -56,3 +81,35 @@ func main() {
 	fmt.Println("║  4. 访问 http://localhost:8080/health")
 	fmt.Println("╚══════════════════════════════════════════════╝")
 }
+
+// introspect 读取已有 SQLite 数据库的表结构, 写出配置文件
+func introspect(args []string) {
+	fs := flag.NewFlagSet("introspect", flag.ExitOnError)
+	dbFile := fs.String("db", "", "SQLite数据库文件路径")
+	output := fs.String("output", "schema.json", "输出的JSON配置文件路径")
+	fs.Parse(args)
+	if *dbFile == "" {
+		fmt.Fprintln(os.Stderr, "用法: go run main.go introspect -db data.db [-output schema.json]")
+		os.Exit(2)
+	}
+
+	fmt.Printf("🔍 读取数据库结构: %s\n", *dbFile)
+	schemaConfig, err := config.NewParser().Introspect(*dbFile)
+	if err != nil {
+		log.Fatalf("❌ 读取失败: %v", err)
+	}
+	fmt.Printf("   ✅ 读取到 %d 个表, %d 个关系\n", len(schemaConfig.Tables), len(schemaConfig.Relations))
+	for _, t := range schemaConfig.Tables {
+		fmt.Printf("      - %s: %d 个字段\n", t.Name, len(t.Fields))
+	}
+
+	data, err := json.MarshalIndent(schemaConfig, "", "  ")
+	if err != nil {
+		log.Fatalf("❌ 序列化配置失败: %v", err)
+	}
+	if err := os.WriteFile(*output, append(data, '\n'), 0644); err != nil {
+		log.Fatalf("❌ 写入配置失败: %v", err)
+	}
+	fmt.Printf("📝 已写入配置: %s\n", *output)
+	fmt.Printf("   下一步: go run main.go -config %s -output my-api -mod my-api\n", *output)
+}
@@ -1,25 +1,65 @@
 package models
 
+import "strings"
+
 // SchemaConfig 顶层配置结构
 type SchemaConfig struct {
-	Version   string     `json:"version"`
-	Tables    []Table    `json:"tables"`
-	Relations []Relation `json:"relations"`
+	Version     string      `json:"version"`
+	Description string      `json:"description"`
+	Tables      []Table     `json:"tables"`
+	Relations   []Relation  `json:"relations"`
+	Auth        *AuthConfig `json:"auth,omitempty"` // 认证配置, 为空时接口不做认证
+}
+
+// AuthConfig 认证配置: 生成用户表、注册登录接口、JWT 中间件和按表按操作的角色规则
+type AuthConfig struct {
+	UsersTable  string                         `json:"usersTable"`  // 用户表名, 默认 users
+	Roles       []string                       `json:"roles"`       // 角色列表, 第一个为管理员角色, 默认 admin, user
+	DefaultRole string                         `json:"defaultRole"` // 注册用户的角色, 默认为最后一个角色
+	TokenTTL    string                         `json:"tokenTTL"`    // 令牌有效期, 如 24h, 默认 24h
+	Rules       map[string]map[string][]string `json:"rules"`       // 表名(* 为默认) -> 操作 -> 允许的角色
 }
 
 // Table 表定义
 type Table struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
-	PrimaryKey  string  `json:"primaryKey"`
+	PrimaryKey  string  `json:"primaryKey"` // 主键字段名, 复合主键用逗号分隔, 如 user_id,role_id
 	Fields      []Field `json:"fields"`
+	RenamedFrom string  `json:"renamedFrom,omitempty"` // 重命名前的表名, 用于生成迁移
+	SoftDelete  bool    `json:"softDelete,omitempty"`  // 软删除: 删除时只设置 deleted_at, 可恢复
+	Version     bool    `json:"version,omitempty"`     // 乐观锁: 添加 version 列, 每次更新加 1, 接口通过 ETag/If-Match 校验版本
+}
+
+// PrimaryKeys 返回主键字段名列表, 未配置主键时为空
+func (t Table) PrimaryKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(t.PrimaryKey, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsPrimaryKey 判断字段是否为主键（或复合主键的一部分）
+func (t Table) IsPrimaryKey(name string) bool {
+	for _, key := range t.PrimaryKeys() {
+		if key == name {
+			return true
+		}
+	}
+	return false
 }
 
 // Field 字段定义
 type Field struct {
 	Name          string `json:"name"`
-	Type          string `json:"type"`          // number, string, boolean, text, date, float
-	Length        int    `json:"length"`         // 字段长度
+	Type          string `json:"type"`          // number, bigint, float, decimal, string, text, enum, boolean, date, datetime, time, json, binary
+	Length        int    `json:"length"`         // 字段长度, binary 为最大字节数
+	Precision     int    `json:"precision,omitempty"` // decimal 总位数, 默认 10
+	Scale         int    `json:"scale,omitempty"`     // decimal 小数位数, 默认 0
+	Storage       string `json:"storage,omitempty"`   // decimal 存储方式: text（默认, 字符串）或 cents（按小数位放大后的整数）
 	Format        string `json:"format"`         // uuid, email, url, date, datetime 等
 	Required      bool   `json:"required"`       // 是否必填
 	Unique        bool   `json:"unique"`         // 是否唯一
@@ -27,6 +67,47 @@ type Field struct {
 	Default       any    `json:"default"`        // 默认值
 	Comment       string `json:"comment"`        // 字段注释
 	Enum          []any  `json:"enum"`           // 枚举值
+	RenamedFrom   string `json:"renamedFrom,omitempty"` // 重命名前的字段名, 用于生成迁移
+}
+
+// DefaultPrecision decimal 字段未配置 precision 时的总位数
+const DefaultPrecision = 10
+
+// DecimalPrecision 返回 decimal 字段的总位数, 未配置时为 DefaultPrecision
+func (f Field) DecimalPrecision() int {
+	if f.Precision > 0 {
+		return f.Precision
+	}
+	return DefaultPrecision
+}
+
+// IsCents 判断 decimal 字段是否按整数（放大 10^scale 倍）存储
+func (f Field) IsCents() bool {
+	return f.Type == "decimal" && f.Storage == "cents"
+}
+
+// IsDecimalText 判断 decimal 字段是否按字符串存储
+func (f Field) IsDecimalText() bool {
+	return f.Type == "decimal" && f.Storage != "cents"
+}
+
+// IsInteger 判断字段在 Go 中是否为 int64: number、bigint 以及按整数存储的 decimal
+func (f Field) IsInteger() bool {
+	return f.Type == "number" || f.Type == "bigint" || f.IsCents()
+}
+
+// IsString 判断字段在 Go 中是否为 string: string、text、enum、time 以及按字符串存储的 decimal
+func (f Field) IsString() bool {
+	switch f.Type {
+	case "string", "text", "enum", "time":
+		return true
+	}
+	return f.IsDecimalText()
+}
+
+// IsTime 判断字段在 Go 中是否为 time.Time: date 和 datetime
+func (f Field) IsTime() bool {
+	return f.Type == "date" || f.Type == "datetime"
 }
 
 // Relation 表关系定义
@@ -49,6 +130,7 @@ type GoField struct {
 	JsonTag    string // JSON 标签
 	ValidateTag string // 验证标签
 	Comment    string // 注释
+	Raw        Field  // 原始字段配置（公共字段为零值）
 }
 
 // GoModel Go模型的中间表示
@@ -57,8 +139,13 @@ type GoModel struct {
 	TableName   string    // 数据库表名
 	Description string    // 描述
 	Fields      []GoField // 字段列表
-	PrimaryKey  string    // 主键字段名（Go命名）
+	PrimaryKey  string    // 主键字段名（Go命名）, 复合主键时为第一个字段
+	PrimaryKeys []string  // 所有主键字段名（Go命名）, 复合主键时有多个
 	HasTime     bool      // 是否包含 time.Time 类型
+	SoftDelete  bool      // 是否软删除（包含 DeletedAt 字段）
+	Version     bool      // 是否启用乐观锁（包含 Version 字段）
+
+	Associations []GoAssociation // 关联字段列表
 }
 
 // GoRelation Go关系的中间表示
@@ -69,3 +156,22 @@ type GoRelation struct {
 	ForeignKey   string // 外键字段（Go命名）
 	ReferenceKey string // 引用字段（Go命名）
 }
+
+// GoAssociation 模型关联字段的中间表示
+type GoAssociation struct {
+	GoName          string // 关联字段名（如 Orders、Customer）
+	JsonName        string // JSON 名称，同时作为嵌套路由路径
+	Kind            string // has-one, has-many, belongs-to, many2many
+	Model           string // 关联目标模型（PascalCase）
+	TableName       string // 关联目标表名
+	Description     string // 关联目标描述
+	ForeignKey      string // 外键字段（Go命名）
+	ForeignColumn   string // 外键列名
+	ReferenceKey    string // 引用字段（Go命名）
+	ReferenceColumn string // 引用字段列名
+	KeyType         string // 外键的 Go 类型; 多对多为目标模型引用字段的 Go 类型
+	JoinTable       string // 多对多中间表名
+	JoinModel       string // 多对多中间表模型
+	JoinForeign     string // 中间表中指向本模型的外键（Go命名）
+	JoinReference   string // 中间表中指向目标模型的外键（Go命名）
+}